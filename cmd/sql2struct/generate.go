@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generate emits the Go source that has the struct for each table.
+// The nullable columns become optional.Option[T] fields, and the NOT NULL columns become the bare type fields; each field has `db` struct tag.
+func generate(packageName string, tables []*table, mapper *typeMapper) ([]byte, error) {
+	imports := make(map[string]bool)
+	var body bytes.Buffer
+	for _, t := range tables {
+		structName := exportedName(t.name)
+		fmt.Fprintf(&body, "\n// %s represents a row of %s table.\ntype %s struct {\n", structName, t.name, structName)
+
+		used := make(map[string]int)
+		for _, c := range t.columns {
+			typ := mapper.goTypeOf(t.name, c)
+			for _, importPath := range typ.imports {
+				imports[importPath] = true
+			}
+			expr := typ.expr
+			if !c.notNull {
+				expr = "optional.Option[" + expr + "]"
+				imports[optionalImportPath] = true
+			}
+
+			fieldName := exportedName(c.name)
+			used[fieldName]++
+			if n := used[fieldName]; n > 1 {
+				fieldName += strconv.Itoa(n)
+			}
+			fmt.Fprintf(&body, "%s %s `db:%s`\n", fieldName, expr, strconv.Quote(c.name))
+		}
+		body.WriteString("}\n")
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by sql2struct. DO NOT EDIT.\n\npackage %s\n", packageName)
+	if len(imports) > 0 {
+		// the standard packages come first, and the others follow them as a separate group (i.e. the same as goimports)
+		var std, others []string
+		for importPath := range imports {
+			if firstElem, _, _ := strings.Cut(importPath, "/"); strings.Contains(firstElem, ".") {
+				others = append(others, importPath)
+				continue
+			}
+			std = append(std, importPath)
+		}
+		sort.Strings(std)
+		sort.Strings(others)
+
+		src.WriteString("\nimport (\n")
+		for _, importPath := range std {
+			src.WriteString(strconv.Quote(importPath) + "\n")
+		}
+		if len(std) > 0 && len(others) > 0 {
+			src.WriteString("\n")
+		}
+		for _, importPath := range others {
+			src.WriteString(strconv.Quote(importPath) + "\n")
+		}
+		src.WriteString(")\n")
+	}
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// commonInitialisms is the list of the initialisms that are written in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "QPS": true, "RAM": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true,
+	"XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// exportedName converts the SQL identifier (e.g. `user_id`) into the exported Go identifier (e.g. `UserID`).
+func exportedName(name string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r >= 0x80)
+	}) {
+		word = strings.ReplaceAll(word, "$", "")
+		if word == "" {
+			continue
+		}
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	ident := sb.String()
+	if ident == "" || ('0' <= ident[0] && ident[0] <= '9') {
+		ident = "X" + ident
+	}
+	return ident
+}
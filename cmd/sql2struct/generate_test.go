@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDDL = `CREATE TABLE user_profiles (
+	id bigserial PRIMARY KEY,
+	user_id bigint NOT NULL,
+	homepage_url text,
+	tags text[],
+	created_at timestamptz NOT NULL
+);
+CREATE TABLE events (
+	id bigint NOT NULL,
+	payload jsonb NOT NULL,
+	"2fa" boolean,
+	two_fa boolean,
+	"TwoFa" boolean
+)`
+
+func TestGenerate(t *testing.T) {
+	tables, err := parseTables(testDDL)
+	assert.NoError(t, err)
+	mapper, err := newTypeMapper("postgres", nil)
+	assert.NoError(t, err)
+
+	generated, err := generate("model", tables, mapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "// Code generated by sql2struct. DO NOT EDIT.\n"+`
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/moznion/go-optional"
+)
+
+// UserProfiles represents a row of user_profiles table.
+type UserProfiles struct {
+	ID          int64                                     `+"`db:\"id\"`"+`
+	UserID      int64                                     `+"`db:\"user_id\"`"+`
+	HomepageURL optional.Option[string]                   `+"`db:\"homepage_url\"`"+`
+	Tags        optional.Option[optional.PGArray[string]] `+"`db:\"tags\"`"+`
+	CreatedAt   time.Time                                 `+"`db:\"created_at\"`"+`
+}
+
+// Events represents a row of events table.
+type Events struct {
+	ID      int64                 `+"`db:\"id\"`"+`
+	Payload json.RawMessage       `+"`db:\"payload\"`"+`
+	X2fa    optional.Option[bool] `+"`db:\"2fa\"`"+`
+	TwoFa   optional.Option[bool] `+"`db:\"two_fa\"`"+`
+	TwoFa2  optional.Option[bool] `+"`db:\"TwoFa\"`"+`
+}
+`, string(generated))
+}
+
+func TestGenerate_withoutImports(t *testing.T) {
+	tables, err := parseTables(`CREATE TABLE counters (name TEXT NOT NULL PRIMARY KEY, value INTEGER NOT NULL)`)
+	assert.NoError(t, err)
+	mapper, err := newTypeMapper("sqlite", nil)
+	assert.NoError(t, err)
+
+	generated, err := generate("db", tables, mapper)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(generated), "import")
+	assert.Contains(t, string(generated), "package db\n")
+}
+
+func TestExportedName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"id":           "ID",
+		"user_id":      "UserID",
+		"api_url":      "APIURL",
+		"createdAt":    "CreatedAt",
+		"display-name": "DisplayName",
+		"1st_place":    "X1stPlace",
+		"_":            "X",
+	} {
+		assert.Equal(t, expected, exportedName(name), name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var stdout bytes.Buffer
+	err := run([]string{"-package", "schema", "-type", "timestamptz=string"}, strings.NewReader(testDDL), &stdout)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), "package schema\n")
+	assert.Contains(t, stdout.String(), "CreatedAt   string")
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "schema.sql")
+	assert.NoError(t, os.WriteFile(input, []byte(testDDL), 0o644))
+	output := filepath.Join(dir, "schema.go")
+	err = run([]string{"-dialect", "postgres", "-o", output, input, input}, strings.NewReader(""), &stdout)
+	assert.NoError(t, err)
+	generated, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(generated), "type UserProfiles"))
+
+	err = run(nil, strings.NewReader("DROP TABLE t"), &stdout)
+	assert.ErrorContains(t, err, "no CREATE TABLE statement found")
+
+	err = run([]string{"-dialect", "oracle"}, strings.NewReader(testDDL), &stdout)
+	assert.ErrorContains(t, err, "unsupported dialect")
+}
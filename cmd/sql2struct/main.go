@@ -0,0 +1,90 @@
+// Command sql2struct generates Go structs from `CREATE TABLE` statements.
+//
+// The nullable columns become optional.Option[T] fields and the NOT NULL columns (including the primary key) become the bare type fields,
+// with `db` struct tags for github.com/moznion/go-optional/sqlrow and github.com/moznion/go-optional/sqlbuilder.
+// This supports the subset of SQLite and PostgreSQL dialects.
+//
+// Usage:
+//
+//	sql2struct [-dialect postgres|sqlite] [-package name] [-o output.go] [-type sql_type=go_type]... [file.sql...]
+//
+// The SQL is read from the files, or the standard input if no file is given. The type mapping can be customized by `-type` flag
+// with the SQL type (e.g. `-type uuid=github.com/google/uuid.UUID`) or the column (e.g. `-type users.settings=encoding/json.RawMessage`).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moznion/go-optional"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sql2struct: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sql2struct", flag.ContinueOnError)
+	dialect := fs.String("dialect", "postgres", "SQL dialect of the input: postgres or sqlite")
+	packageName := fs.String("package", "model", "package name of the generated code")
+	output := fs.String("o", "", "output file (default: standard output)")
+	var typeMappings optional.Option[[]string]
+	optional.FlagVar(fs, &typeMappings, "type", "custom type mapping as sql_type=go_type or table.column=go_type (repeatable)")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	mapper, err := newTypeMapper(*dialect, typeMappings.Unwrap())
+	if err != nil {
+		return err
+	}
+
+	src, err := readSources(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+	tables, err := parseTables(src)
+	if err != nil {
+		return err
+	}
+	if len(tables) <= 0 {
+		return fmt.Errorf("no CREATE TABLE statement found")
+	}
+
+	generated, err := generate(*packageName, tables, mapper)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(generated)
+		return err
+	}
+	return os.WriteFile(*output, generated, 0o644)
+}
+
+func readSources(files []string, stdin io.Reader) (string, error) {
+	if len(files) <= 0 {
+		src, err := io.ReadAll(stdin)
+		return string(src), err
+	}
+
+	sources := make([]string, 0, len(files))
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		sources = append(sources, string(src))
+	}
+	// the last statement of a file may not have the semicolon
+	return strings.Join(sources, ";\n"), nil
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// table is a table definition of `CREATE TABLE` statement.
+type table struct {
+	name    string
+	columns []*column
+}
+
+// column is a column definition of the table.
+type column struct {
+	name string
+	// typ is the declared type as it is, e.g. `varchar(255)` and `integer[]`.
+	typ     string
+	notNull bool
+}
+
+// parseTables parses the `CREATE TABLE` statements in the SQL source. The other statements are ignored.
+func parseTables(src string) ([]*table, error) {
+	var tables []*table
+	for _, stmt := range splitStatements(stripComments(src)) {
+		t, err := parseCreateTable(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+// stripComments replaces the comments (i.e. `-- ...` and `/* ... */`) with a space, with keeping the string literals and the quoted identifiers.
+func stripComments(src string) string {
+	var sb strings.Builder
+	for i := 0; i < len(src); {
+		switch c := src[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				sb.WriteString(src[i:])
+				return sb.String()
+			}
+			sb.WriteString(src[i : i+end+2])
+			i += end + 2
+		case strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return sb.String()
+			}
+			sb.WriteByte(' ')
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			sb.WriteByte(' ')
+			i += end + 4
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// splitStatements splits the SQL source by the semicolons that are not in the string literals, the quoted identifiers and the parentheses.
+func splitStatements(src string) []string {
+	return splitTopLevel(src, ';')
+}
+
+// splitTopLevel splits the text by the separator that is not in the string literals, the quoted identifiers and the parentheses.
+// The blank parts are removed from the result.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"', '`':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				i = len(s)
+				continue
+			}
+			i += end + 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+
+	result := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// parseCreateTable parses the `CREATE TABLE` statement. If the statement is not that, this returns nil.
+func parseCreateTable(stmt string) (*table, error) {
+	tokens := tokenize(stmt)
+	i := 0
+	if !tokenIs(tokens, i, "CREATE") {
+		return nil, nil
+	}
+	i++
+	if tokenIs(tokens, i, "TEMP") || tokenIs(tokens, i, "TEMPORARY") || tokenIs(tokens, i, "UNLOGGED") {
+		i++
+	}
+	if !tokenIs(tokens, i, "TABLE") {
+		return nil, nil
+	}
+	i++
+	if tokenIs(tokens, i, "IF") && tokenIs(tokens, i+1, "NOT") && tokenIs(tokens, i+2, "EXISTS") {
+		i += 3
+	}
+
+	if i >= len(tokens) {
+		return nil, fmt.Errorf("missing table name: %s", stmt)
+	}
+	name := unquoteIdentifier(tokens[i])
+	i++
+	// schema qualified name, e.g. `public.users`
+	for tokenIs(tokens, i, ".") && i+1 < len(tokens) {
+		name = unquoteIdentifier(tokens[i+1])
+		i += 2
+	}
+
+	if i >= len(tokens) || !strings.HasPrefix(tokens[i], "(") {
+		// e.g. `CREATE TABLE t AS SELECT ...`
+		return nil, nil
+	}
+	if end, closed := matchingParen(tokens[i], 0); !closed || end != len(tokens[i]) {
+		return nil, fmt.Errorf("missing ')' of table %s", name)
+	}
+	body := tokens[i][1 : len(tokens[i])-1]
+
+	t := &table{name: name}
+	var primaryKeys []string
+	for _, def := range splitTopLevel(body, ',') {
+		defTokens := tokenize(def)
+		if isTableConstraint(defTokens) {
+			primaryKeys = append(primaryKeys, primaryKeyColumns(defTokens)...)
+			continue
+		}
+		c, err := parseColumn(defTokens)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		t.columns = append(t.columns, c)
+	}
+
+	for _, pk := range primaryKeys {
+		for _, c := range t.columns {
+			if strings.EqualFold(c.name, pk) {
+				c.notNull = true
+			}
+		}
+	}
+	return t, nil
+}
+
+func isTableConstraint(tokens []string) bool {
+	for _, keyword := range []string{"CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "EXCLUDE"} {
+		if tokenIs(tokens, 0, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyColumns returns the column names of the table constraint of `PRIMARY KEY (a, b)`.
+func primaryKeyColumns(tokens []string) []string {
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokenIs(tokens, i, "PRIMARY") && tokenIs(tokens, i+1, "KEY") && strings.HasPrefix(tokens[i+2], "(") {
+			group := tokens[i+2]
+			var columns []string
+			for _, c := range splitTopLevel(group[1:len(group)-1], ',') {
+				// the column may have the sort order, e.g. `id DESC`
+				columns = append(columns, unquoteIdentifier(tokenize(c)[0]))
+			}
+			return columns
+		}
+	}
+	return nil
+}
+
+// columnConstraintKeywords are the keywords that terminate the type of the column definition.
+var columnConstraintKeywords = map[string]bool{
+	"CONSTRAINT":    true,
+	"NOT":           true,
+	"NULL":          true,
+	"PRIMARY":       true,
+	"UNIQUE":        true,
+	"CHECK":         true,
+	"DEFAULT":       true,
+	"REFERENCES":    true,
+	"COLLATE":       true,
+	"GENERATED":     true,
+	"AS":            true,
+	"AUTOINCREMENT": true,
+}
+
+func parseColumn(tokens []string) (*column, error) {
+	if len(tokens) <= 0 {
+		return nil, fmt.Errorf("empty column definition")
+	}
+	c := &column{name: unquoteIdentifier(tokens[0])}
+
+	i := 1
+	var typ strings.Builder
+	for ; i < len(tokens) && !columnConstraintKeywords[strings.ToUpper(tokens[i])]; i++ {
+		tok := tokens[i]
+		if typ.Len() > 0 && !strings.HasPrefix(tok, "(") && !strings.HasPrefix(tok, "[") {
+			typ.WriteByte(' ')
+		}
+		typ.WriteString(tok)
+	}
+	c.typ = typ.String()
+
+	if isSerialType(c.typ) {
+		c.notNull = true
+	}
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokenIs(tokens, i, "NOT") && tokenIs(tokens, i+1, "NULL"):
+			c.notNull = true
+		case tokenIs(tokens, i, "PRIMARY") && tokenIs(tokens, i+1, "KEY"):
+			c.notNull = true
+		case tokenIs(tokens, i, "GENERATED") && tokenIs(tokens, i+1, "ALWAYS") && tokenIs(tokens, i+2, "AS") && tokenIs(tokens, i+3, "IDENTITY"),
+			tokenIs(tokens, i, "GENERATED") && tokenIs(tokens, i+1, "BY") && tokenIs(tokens, i+2, "DEFAULT"):
+			// the identity column is implicitly NOT NULL
+			c.notNull = true
+		}
+	}
+	return c, nil
+}
+
+func isSerialType(typ string) bool {
+	switch strings.ToLower(typ) {
+	case "smallserial", "serial", "bigserial", "serial2", "serial4", "serial8":
+		return true
+	}
+	return false
+}
+
+// tokenize splits the SQL text into the tokens: the words, the string literals, the quoted identifiers, the parenthesized groups (as a whole), the brackets (as a whole) and the other symbols.
+func tokenize(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				tokens = append(tokens, s[i:])
+				return tokens
+			}
+			tokens = append(tokens, s[i:i+end+2])
+			i += end + 2
+		case c == '(':
+			end, _ := matchingParen(s, i)
+			tokens = append(tokens, s[i:end])
+			i = end
+		case c == '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				tokens = append(tokens, s[i:])
+				return tokens
+			}
+			tokens = append(tokens, s[i:i+end+1])
+			i += end + 1
+		case isWordChar(c):
+			start := i
+			for i < len(s) && isWordChar(s[i]) {
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		default:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+// matchingParen returns the position right after the parenthesis that closes the one at the start.
+// If the parenthesis isn't closed, this returns the end of the string and false.
+func matchingParen(s string, start int) (int, bool) {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"', '`':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return len(s), false
+			}
+			i += end + 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+	return len(s), false
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c >= 0x80
+}
+
+func tokenIs(tokens []string, i int, keyword string) bool {
+	return i < len(tokens) && strings.EqualFold(tokens[i], keyword)
+}
+
+// unquoteIdentifier removes the quotes of the identifier, i.e. the double quotes, the backquotes and the square brackets.
+func unquoteIdentifier(s string) string {
+	if len(s) >= 2 {
+		switch first, last := s[0], s[len(s)-1]; {
+		case first == '"' && last == '"', first == '`' && last == '`', first == '[' && last == ']':
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTables(t *testing.T) {
+	tables, err := parseTables(`
+-- the comment; with semicolon
+CREATE TABLE IF NOT EXISTS public."users" (
+	id bigserial PRIMARY KEY,
+	name varchar(255) NOT NULL DEFAULT 'NOT NULL; really',
+	nickname text /* NOT NULL */,
+	score numeric(10, 2) CHECK (score >= 0),
+	tags text[],
+	created_at timestamp(3) with time zone NOT NULL DEFAULT now(),
+	identity_id integer GENERATED ALWAYS AS IDENTITY,
+	CONSTRAINT users_name_unique UNIQUE (name)
+);
+CREATE INDEX users_name ON users(name);
+CREATE TEMP TABLE [memberships] (
+	` + "`user_id`" + ` INTEGER,
+	"group_id" INTEGER,
+	role,
+	PRIMARY KEY (user_id, "group_id" DESC)
+) WITHOUT ROWID;
+CREATE TABLE copied AS SELECT * FROM users`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*table{
+		{
+			name: "users",
+			columns: []*column{
+				{name: "id", typ: "bigserial", notNull: true},
+				{name: "name", typ: "varchar(255)", notNull: true},
+				{name: "nickname", typ: "text"},
+				{name: "score", typ: "numeric(10, 2)"},
+				{name: "tags", typ: "text[]"},
+				{name: "created_at", typ: "timestamp(3) with time zone", notNull: true},
+				{name: "identity_id", typ: "integer", notNull: true},
+			},
+		},
+		{
+			name: "memberships",
+			columns: []*column{
+				{name: "user_id", typ: "INTEGER", notNull: true},
+				{name: "group_id", typ: "INTEGER", notNull: true},
+				{name: "role", typ: ""},
+			},
+		},
+	}, tables)
+}
+
+func TestParseTables_nullableByDefault(t *testing.T) {
+	tables, err := parseTables(`CREATE TABLE t (a int NULL, b int DEFAULT NULL, c serial, d int REFERENCES other (id) ON DELETE CASCADE NOT NULL)`)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, []*column{
+		{name: "a", typ: "int"},
+		{name: "b", typ: "int"},
+		{name: "c", typ: "serial", notNull: true},
+		{name: "d", typ: "int", notNull: true},
+	}, tables[0].columns)
+}
+
+func TestParseTables_noTable(t *testing.T) {
+	tables, err := parseTables(`CREATE INDEX idx ON t(a); DROP TABLE t;`)
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+}
+
+func TestParseTables_unclosedBody(t *testing.T) {
+	for _, input := range []string{
+		`CREATE TABLE t (`,
+		`CREATE TABLE t (id int, name text`,
+		`CREATE TABLE t (id int, price numeric(10,2)`,
+		`CREATE TABLE t (id int, name text DEFAULT ')'`,
+	} {
+		_, err := parseTables(input)
+		assert.ErrorContains(t, err, "missing ')'", input)
+	}
+}
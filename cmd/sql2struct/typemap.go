@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const optionalImportPath = "github.com/moznion/go-optional"
+
+// goType is the Go type expression and the import paths that the expression requires.
+type goType struct {
+	expr    string
+	imports []string
+}
+
+var (
+	goTypeString   = goType{expr: "string"}
+	goTypeBool     = goType{expr: "bool"}
+	goTypeInt16    = goType{expr: "int16"}
+	goTypeInt32    = goType{expr: "int32"}
+	goTypeInt64    = goType{expr: "int64"}
+	goTypeFloat32  = goType{expr: "float32"}
+	goTypeFloat64  = goType{expr: "float64"}
+	goTypeBytes    = goType{expr: "[]byte"}
+	goTypeAny      = goType{expr: "any"}
+	goTypeTime     = goType{expr: "time.Time", imports: []string{"time"}}
+	goTypeJSONText = goType{expr: "json.RawMessage", imports: []string{"encoding/json"}}
+)
+
+// postgresTypes is the default type mapping of PostgreSQL. The key is the normalized type name.
+var postgresTypes = map[string]goType{
+	"smallint":                    goTypeInt16,
+	"int2":                        goTypeInt16,
+	"smallserial":                 goTypeInt16,
+	"serial2":                     goTypeInt16,
+	"integer":                     goTypeInt32,
+	"int":                         goTypeInt32,
+	"int4":                        goTypeInt32,
+	"serial":                      goTypeInt32,
+	"serial4":                     goTypeInt32,
+	"bigint":                      goTypeInt64,
+	"int8":                        goTypeInt64,
+	"bigserial":                   goTypeInt64,
+	"serial8":                     goTypeInt64,
+	"real":                        goTypeFloat32,
+	"float4":                      goTypeFloat32,
+	"double precision":            goTypeFloat64,
+	"float8":                      goTypeFloat64,
+	"float":                       goTypeFloat64,
+	"numeric":                     goTypeString,
+	"decimal":                     goTypeString,
+	"boolean":                     goTypeBool,
+	"bool":                        goTypeBool,
+	"text":                        goTypeString,
+	"varchar":                     goTypeString,
+	"character varying":           goTypeString,
+	"char":                        goTypeString,
+	"character":                   goTypeString,
+	"bpchar":                      goTypeString,
+	"citext":                      goTypeString,
+	"uuid":                        goTypeString,
+	"bytea":                       goTypeBytes,
+	"date":                        goTypeTime,
+	"time":                        goTypeTime,
+	"timetz":                      goTypeTime,
+	"time with time zone":         goTypeTime,
+	"time without time zone":      goTypeTime,
+	"timestamp":                   goTypeTime,
+	"timestamptz":                 goTypeTime,
+	"timestamp with time zone":    goTypeTime,
+	"timestamp without time zone": goTypeTime,
+	"json":                        goTypeJSONText,
+	"jsonb":                       goTypeJSONText,
+}
+
+// sqliteTypes is the default type mapping of SQLite that has priority over the type affinity.
+var sqliteTypes = map[string]goType{
+	"boolean":   goTypeBool,
+	"bool":      goTypeBool,
+	"date":      goTypeTime,
+	"datetime":  goTypeTime,
+	"timestamp": goTypeTime,
+	"numeric":   goTypeString,
+	"decimal":   goTypeString,
+	"":          goTypeAny,
+}
+
+// typeMapper maps the column types to the Go types.
+type typeMapper struct {
+	dialect string
+	// types is the custom mapping by the normalized type name (e.g. `uuid` and `integer[]`).
+	types map[string]goType
+	// columns is the custom mapping by the column (i.e. `table.column`).
+	columns map[string]goType
+}
+
+// newTypeMapper makes the typeMapper of the dialect (sqlite or postgres) with the custom mappings.
+// Each mapping is `sql_type=go_type` or `table.column=go_type`, and the Go type can be qualified by the import path (e.g. `github.com/google/uuid.UUID`).
+func newTypeMapper(dialect string, mappings []string) (*typeMapper, error) {
+	if dialect != "sqlite" && dialect != "postgres" {
+		return nil, fmt.Errorf("unsupported dialect %q: must be sqlite or postgres", dialect)
+	}
+
+	m := &typeMapper{
+		dialect: dialect,
+		types:   make(map[string]goType),
+		columns: make(map[string]goType),
+	}
+	for _, mapping := range mappings {
+		key, value, found := strings.Cut(mapping, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid type mapping %q: must be sql_type=go_type or table.column=go_type", mapping)
+		}
+
+		t := parseGoType(value)
+		if strings.Contains(key, ".") {
+			m.columns[key] = t
+			continue
+		}
+		base, dims := normalizeType(key)
+		m.types[base+strings.Repeat("[]", dims)] = t
+	}
+	return m, nil
+}
+
+// parseGoType parses the Go type that can be qualified by the import path, e.g. `int64`, `[]byte` and `github.com/google/uuid.UUID`.
+func parseGoType(s string) goType {
+	for _, prefix := range []string{"[]", "*"} {
+		if strings.HasPrefix(s, prefix) {
+			elem := parseGoType(s[len(prefix):])
+			return goType{expr: prefix + elem.expr, imports: elem.imports}
+		}
+	}
+
+	dot := strings.LastIndexByte(s, '.')
+	if dot < 0 {
+		return goType{expr: s}
+	}
+	importPath := s[:dot]
+	return goType{expr: path.Base(importPath) + s[dot:], imports: []string{importPath}}
+}
+
+// goTypeOf returns the Go type of the column. The nullability of the column is not dealt with here.
+func (m *typeMapper) goTypeOf(tableName string, c *column) goType {
+	if t, ok := m.columns[tableName+"."+c.name]; ok {
+		return t
+	}
+
+	base, dims := normalizeType(c.typ)
+	if t, ok := m.types[base+strings.Repeat("[]", dims)]; ok {
+		return t
+	}
+
+	t := m.baseGoTypeOf(base)
+	// the PostgreSQL array can contain NULL elements, and the multi-dimensional array is represented by the nested one
+	for i := 0; i < dims; i++ {
+		t = goType{
+			expr:    "optional.PGArray[" + t.expr + "]",
+			imports: append([]string{optionalImportPath}, t.imports...),
+		}
+	}
+	return t
+}
+
+func (m *typeMapper) baseGoTypeOf(base string) goType {
+	if t, ok := m.types[base]; ok {
+		return t
+	}
+
+	if m.dialect == "postgres" {
+		if t, ok := postgresTypes[base]; ok {
+			return t
+		}
+		// e.g. the enum types; those are dealt with as the text
+		return goTypeString
+	}
+
+	if t, ok := sqliteTypes[base]; ok {
+		return t
+	}
+	// the type affinity of SQLite: https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+	switch {
+	case strings.Contains(base, "int"):
+		return goTypeInt64
+	case strings.Contains(base, "char"), strings.Contains(base, "clob"), strings.Contains(base, "text"):
+		return goTypeString
+	case strings.Contains(base, "blob"):
+		return goTypeBytes
+	case strings.Contains(base, "real"), strings.Contains(base, "floa"), strings.Contains(base, "doub"):
+		return goTypeFloat64
+	}
+	return goTypeString
+}
+
+// normalizeType returns the lower-cased type name without the parameters (e.g. `varchar(255)` to `varchar`), and the number of the array dimensions.
+func normalizeType(typ string) (string, int) {
+	var sb strings.Builder
+	dims := 0
+	depth := 0
+	for i := 0; i < len(typ); i++ {
+		switch c := typ[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth > 0:
+		case c == '[':
+			dims++
+			if end := strings.IndexByte(typ[i:], ']'); end >= 0 {
+				i += end
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return strings.Join(strings.Fields(strings.ToLower(sb.String())), " "), dims
+}
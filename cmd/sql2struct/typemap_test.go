@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeMapper_postgres(t *testing.T) {
+	m, err := newTypeMapper("postgres", nil)
+	assert.NoError(t, err)
+
+	for typ, expected := range map[string]goType{
+		"smallint":                    goTypeInt16,
+		"INTEGER":                     goTypeInt32,
+		"bigserial":                   goTypeInt64,
+		"double precision":            goTypeFloat64,
+		"numeric(10, 2)":              goTypeString,
+		"character varying(20)":       goTypeString,
+		"boolean":                     goTypeBool,
+		"bytea":                       goTypeBytes,
+		"timestamp(3) with time zone": goTypeTime,
+		"jsonb":                       goTypeJSONText,
+		"mood_enum":                   goTypeString,
+		"integer[]":                   {expr: "optional.PGArray[int32]", imports: []string{optionalImportPath}},
+		"timestamptz[][]":             {expr: "optional.PGArray[optional.PGArray[time.Time]]", imports: []string{optionalImportPath, optionalImportPath, "time"}},
+	} {
+		assert.Equal(t, expected, m.goTypeOf("t", &column{name: "c", typ: typ}), typ)
+	}
+}
+
+func TestTypeMapper_sqlite(t *testing.T) {
+	m, err := newTypeMapper("sqlite", nil)
+	assert.NoError(t, err)
+
+	for typ, expected := range map[string]goType{
+		"INTEGER":          goTypeInt64,
+		"int":              goTypeInt64,
+		"UNSIGNED BIG INT": goTypeInt64,
+		"VARCHAR(255)":     goTypeString,
+		"CLOB":             goTypeString,
+		"TEXT":             goTypeString,
+		"BLOB":             goTypeBytes,
+		"REAL":             goTypeFloat64,
+		"DOUBLE PRECISION": goTypeFloat64,
+		"FLOAT":            goTypeFloat64,
+		"BOOLEAN":          goTypeBool,
+		"DATETIME":         goTypeTime,
+		"DECIMAL(10,5)":    goTypeString,
+		"":                 goTypeAny,
+	} {
+		assert.Equal(t, expected, m.goTypeOf("t", &column{name: "c", typ: typ}), typ)
+	}
+}
+
+func TestTypeMapper_custom(t *testing.T) {
+	m, err := newTypeMapper("postgres", []string{
+		"uuid=github.com/google/uuid.UUID",
+		"UUID[] = []github.com/google/uuid.UUID",
+		"numeric=float64",
+		"users.settings=*encoding/json.RawMessage",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, goType{expr: "uuid.UUID", imports: []string{"github.com/google/uuid"}}, m.goTypeOf("users", &column{name: "id", typ: "uuid"}))
+	assert.Equal(t, goType{expr: "[]uuid.UUID", imports: []string{"github.com/google/uuid"}}, m.goTypeOf("users", &column{name: "ids", typ: "uuid[]"}))
+	assert.Equal(t, goType{expr: "optional.PGArray[float64]", imports: []string{optionalImportPath}}, m.goTypeOf("users", &column{name: "scores", typ: "numeric(10,2)[]"}))
+	assert.Equal(t, goType{expr: "*json.RawMessage", imports: []string{"encoding/json"}}, m.goTypeOf("users", &column{name: "settings", typ: "jsonb"}))
+	assert.Equal(t, goTypeJSONText, m.goTypeOf("groups", &column{name: "settings", typ: "jsonb"}))
+}
+
+func TestNewTypeMapper_shouldRaiseError(t *testing.T) {
+	_, err := newTypeMapper("mysql", nil)
+	assert.ErrorContains(t, err, "unsupported dialect")
+
+	_, err = newTypeMapper("postgres", []string{"uuid"})
+	assert.ErrorContains(t, err, "invalid type mapping")
+
+	_, err = newTypeMapper("postgres", []string{"uuid="})
+	assert.ErrorContains(t, err, "invalid type mapping")
+}
@@ -0,0 +1,299 @@
+// Package ddl generates the DDL (i.e. `CREATE TABLE` and `CREATE INDEX` statements) from the structs that have optional.Option fields.
+//
+// The columns are the struct fields that have `db:"column_name"` struct tag, in the order of the declaration (the fields of the embedded structs are also dealt with).
+// The optional.Option[T] fields become NULL columns, and the others become NOT NULL columns. The column types are mapped from the Go types per dialect.
+//
+// The details of the columns can be specified by `ddl` struct tag, which is a semicolon-separated list of the following options:
+//   - pk: the column is (a part of) the primary key
+//   - autoIncrement: the column is auto-incremented (e.g. `AUTOINCREMENT` of SQLite, `GENERATED BY DEFAULT AS IDENTITY` of PostgreSQL and `AUTO_INCREMENT` of MySQL)
+//   - default:<expression>: the default value of the column, e.g. `default:CURRENT_TIMESTAMP` and `default:'active'`
+//   - unique: the column has UNIQUE constraint
+//   - index or index:<name>: the column is indexed. The columns that have the same index name make the composite index in the order of the declaration
+//   - uniqueIndex or uniqueIndex:<name>: the same as index, but the index is unique
+//   - type:<column type>: the column type that overrides the mapping, e.g. `type:VARCHAR(64)`
+package ddl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Dialect represents the SQL dialect of the generated DDL.
+type Dialect int
+
+const (
+	// SQLite is the dialect of SQLite.
+	SQLite Dialect = iota
+	// Postgres is the dialect of PostgreSQL.
+	Postgres
+	// MySQL is the dialect of MySQL.
+	MySQL
+)
+
+// valid returns whether the dialect is one of the supported dialects or not.
+func (d Dialect) valid() bool {
+	return d == SQLite || d == Postgres || d == MySQL
+}
+
+var (
+	// ErrInvalidStruct represents the error that is raised when the type is not a struct (or a pointer to that).
+	ErrInvalidStruct = errors.New("type must be a struct or a pointer to a struct")
+	// ErrNoColumns represents the error that is raised when the struct doesn't have any column.
+	ErrNoColumns = errors.New("no columns")
+	// ErrUnsupportedDialect represents the error that is raised when the dialect is not one of SQLite, Postgres and MySQL.
+	ErrUnsupportedDialect = errors.New("unsupported dialect")
+)
+
+// column is the column definition that is derived from the struct field.
+type column struct {
+	name          string
+	typ           string
+	nullable      bool
+	pk            bool
+	autoIncrement bool
+	unique        bool
+	defaultValue  string
+	hasDefault    bool
+}
+
+// index is the index definition.
+type index struct {
+	name    string
+	unique  bool
+	columns []string
+}
+
+// CreateTable generates the DDL statements of the table from the struct T: the `CREATE TABLE` statement comes first, and the `CREATE INDEX` statements follow that.
+func CreateTable[T any](table string, dialect Dialect) ([]string, error) {
+	if !dialect.valid() {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedDialect, dialect)
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, ErrInvalidStruct
+	}
+
+	var (
+		columns []*column
+		indexes []*index
+	)
+	err := collectColumns(table, typ, dialect, &columns, &indexes)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) <= 0 {
+		return nil, ErrNoColumns
+	}
+
+	var primaryKeys []string
+	for _, c := range columns {
+		if c.pk {
+			primaryKeys = append(primaryKeys, c.name)
+		}
+		if c.autoIncrement && dialect == SQLite && !c.pk {
+			return nil, fmt.Errorf("column %q: autoIncrement of SQLite requires pk", c.name)
+		}
+	}
+	// SQLite supports AUTOINCREMENT only for the column of `INTEGER PRIMARY KEY`, so the primary key is declared in the column definition
+	inlinePrimaryKey := dialect == SQLite && len(primaryKeys) == 1 && columns[indexOfColumn(columns, primaryKeys[0])].autoIncrement
+	if dialect == SQLite && len(primaryKeys) > 1 {
+		for _, c := range columns {
+			if c.autoIncrement {
+				return nil, fmt.Errorf("column %q: autoIncrement of SQLite can't be used with the composite primary key", c.name)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE ")
+	sb.WriteString(quoteIdentifier(table, dialect))
+	sb.WriteString(" (\n")
+	for i, c := range columns {
+		if i > 0 {
+			sb.WriteString(",\n")
+		}
+		sb.WriteString("\t")
+		writeColumn(&sb, c, dialect, inlinePrimaryKey)
+	}
+	if len(primaryKeys) > 0 && !inlinePrimaryKey {
+		sb.WriteString(",\n\tPRIMARY KEY (")
+		writeIdentifiers(&sb, primaryKeys, dialect)
+		sb.WriteString(")")
+	}
+	sb.WriteString("\n)")
+
+	statements := []string{sb.String()}
+	for _, idx := range indexes {
+		var sb strings.Builder
+		sb.WriteString("CREATE ")
+		if idx.unique {
+			sb.WriteString("UNIQUE ")
+		}
+		sb.WriteString("INDEX ")
+		sb.WriteString(quoteIdentifier(idx.name, dialect))
+		sb.WriteString(" ON ")
+		sb.WriteString(quoteIdentifier(table, dialect))
+		sb.WriteString(" (")
+		writeIdentifiers(&sb, idx.columns, dialect)
+		sb.WriteString(")")
+		statements = append(statements, sb.String())
+	}
+	return statements, nil
+}
+
+func writeColumn(sb *strings.Builder, c *column, dialect Dialect, inlinePrimaryKey bool) {
+	sb.WriteString(quoteIdentifier(c.name, dialect))
+	sb.WriteString(" ")
+	sb.WriteString(c.typ)
+	if c.nullable {
+		sb.WriteString(" NULL")
+	} else {
+		sb.WriteString(" NOT NULL")
+	}
+	if c.pk && inlinePrimaryKey {
+		sb.WriteString(" PRIMARY KEY")
+	}
+	if c.autoIncrement {
+		switch dialect {
+		case SQLite:
+			sb.WriteString(" AUTOINCREMENT")
+		case Postgres:
+			sb.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+		case MySQL:
+			sb.WriteString(" AUTO_INCREMENT")
+		}
+	}
+	if c.unique {
+		sb.WriteString(" UNIQUE")
+	}
+	if c.hasDefault {
+		sb.WriteString(" DEFAULT ")
+		sb.WriteString(c.defaultValue)
+	}
+}
+
+func writeIdentifiers(sb *strings.Builder, identifiers []string, dialect Dialect) {
+	for i, identifier := range identifiers {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(quoteIdentifier(identifier, dialect))
+	}
+}
+
+// quoteIdentifier quotes the identifier by the double quotes, or the backquotes for MySQL.
+func quoteIdentifier(identifier string, dialect Dialect) string {
+	if dialect == MySQL {
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func indexOfColumn(columns []*column, name string) int {
+	for i, c := range columns {
+		if c.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// collectColumns collects the columns and the indexes from the fields of the struct type, including the fields of the embedded structs.
+func collectColumns(table string, typ reflect.Type, dialect Dialect, columns *[]*column, indexes *[]*index) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, hasTag := field.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		if hasTag && field.IsExported() {
+			name, _, _ := strings.Cut(tag, ",")
+			c, err := columnOf(table, name, field, dialect, indexes)
+			if err != nil {
+				return err
+			}
+			if indexOfColumn(*columns, c.name) >= 0 {
+				return fmt.Errorf("duplicate column %q", c.name)
+			}
+			*columns = append(*columns, c)
+			continue
+		}
+
+		if field.Anonymous {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				err := collectColumns(table, fieldType, dialect, columns, indexes)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func columnOf(table string, name string, field reflect.StructField, dialect Dialect, indexes *[]*index) (*column, error) {
+	c := &column{name: name}
+	var explicitType string
+	for _, option := range strings.Split(field.Tag.Get("ddl"), ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), ":")
+		switch key {
+		case "":
+		case "pk":
+			c.pk = true
+		case "autoIncrement":
+			c.autoIncrement = true
+		case "unique":
+			c.unique = true
+		case "default":
+			c.defaultValue = value
+			c.hasDefault = true
+		case "type":
+			explicitType = value
+		case "index", "uniqueIndex":
+			indexName := value
+			if indexName == "" {
+				indexName = "idx_" + table + "_" + name
+			}
+			addIndex(indexes, indexName, key == "uniqueIndex", name)
+		default:
+			return nil, fmt.Errorf("field %s: unknown ddl tag option %q", field.Name, key)
+		}
+	}
+
+	typ, nullable, err := columnTypeOf(field.Type, dialect)
+	if explicitType != "" {
+		typ, err = explicitType, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	c.typ = typ
+	c.nullable = nullable
+
+	if c.pk && c.nullable {
+		return nil, fmt.Errorf("field %s: the primary key column must not be nullable", field.Name)
+	}
+	return c, nil
+}
+
+func addIndex(indexes *[]*index, name string, unique bool, column string) {
+	for _, idx := range *indexes {
+		if idx.name == name {
+			idx.columns = append(idx.columns, column)
+			idx.unique = idx.unique || unique
+			return
+		}
+	}
+	*indexes = append(*indexes, &index{name: name, unique: unique, columns: []string{column}})
+}
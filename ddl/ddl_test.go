@@ -0,0 +1,246 @@
+package ddl
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTimestamps struct {
+	CreatedAt time.Time                  `db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt optional.Option[time.Time] `db:"updated_at"`
+}
+
+type testUser struct {
+	ID       int64                   `db:"id" ddl:"pk;autoIncrement"`
+	Email    string                  `db:"email" ddl:"unique"`
+	Name     string                  `db:"name" ddl:"index"`
+	Nickname optional.Option[string] `db:"nickname"`
+	Status   string                  `db:"status" ddl:"type:VARCHAR(16);default:'active';index:idx_users_status_age"`
+	Age      optional.Option[int32]  `db:"age" ddl:"index:idx_users_status_age"`
+	Ignored  string                  `db:"-"`
+	NoTag    string
+	testTimestamps
+}
+
+func TestCreateTable_SQLite(t *testing.T) {
+	statements, err := CreateTable[testUser]("users", SQLite)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`CREATE TABLE "users" (
+	"id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+	"email" TEXT NOT NULL UNIQUE,
+	"name" TEXT NOT NULL,
+	"nickname" TEXT NULL,
+	"status" VARCHAR(16) NOT NULL DEFAULT 'active',
+	"age" INTEGER NULL,
+	"created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" DATETIME NULL
+)`,
+		`CREATE INDEX "idx_users_name" ON "users" ("name")`,
+		`CREATE INDEX "idx_users_status_age" ON "users" ("status", "age")`,
+	}, statements)
+}
+
+func TestCreateTable_Postgres(t *testing.T) {
+	statements, err := CreateTable[*testUser]("users", Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`CREATE TABLE "users" (
+	"id" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"email" TEXT NOT NULL UNIQUE,
+	"name" TEXT NOT NULL,
+	"nickname" TEXT NULL,
+	"status" VARCHAR(16) NOT NULL DEFAULT 'active',
+	"age" INTEGER NULL,
+	"created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP WITH TIME ZONE NULL,
+	PRIMARY KEY ("id")
+)`,
+		`CREATE INDEX "idx_users_name" ON "users" ("name")`,
+		`CREATE INDEX "idx_users_status_age" ON "users" ("status", "age")`,
+	}, statements)
+}
+
+func TestCreateTable_MySQL(t *testing.T) {
+	statements, err := CreateTable[testUser]("users", MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE `users` (\n" +
+			"\t`id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+			"\t`email` VARCHAR(255) NOT NULL UNIQUE,\n" +
+			"\t`name` VARCHAR(255) NOT NULL,\n" +
+			"\t`nickname` VARCHAR(255) NULL,\n" +
+			"\t`status` VARCHAR(16) NOT NULL DEFAULT 'active',\n" +
+			"\t`age` INT NULL,\n" +
+			"\t`created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+			"\t`updated_at` DATETIME(6) NULL,\n" +
+			"\tPRIMARY KEY (`id`)\n" +
+			")",
+		"CREATE INDEX `idx_users_name` ON `users` (`name`)",
+		"CREATE INDEX `idx_users_status_age` ON `users` (`status`, `age`)",
+	}, statements)
+}
+
+func TestCreateTable_compositePrimaryKeyAndUniqueIndex(t *testing.T) {
+	type membership struct {
+		UserID  int64  `db:"user_id" ddl:"pk"`
+		GroupID int64  `db:"group_id" ddl:"pk;uniqueIndex:idx_group_role"`
+		Role    string `db:"role" ddl:"uniqueIndex:idx_group_role"`
+		Token   string `db:"token" ddl:"uniqueIndex"`
+	}
+
+	statements, err := CreateTable[membership]("memberships", SQLite)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`CREATE TABLE "memberships" (
+	"user_id" INTEGER NOT NULL,
+	"group_id" INTEGER NOT NULL,
+	"role" TEXT NOT NULL,
+	"token" TEXT NOT NULL,
+	PRIMARY KEY ("user_id", "group_id")
+)`,
+		`CREATE UNIQUE INDEX "idx_group_role" ON "memberships" ("group_id", "role")`,
+		`CREATE UNIQUE INDEX "idx_memberships_token" ON "memberships" ("token")`,
+	}, statements)
+}
+
+func TestCreateTable_types(t *testing.T) {
+	type row struct {
+		Bool       bool                                       `db:"bool"`
+		Int8       int8                                       `db:"int8"`
+		Uint64     uint64                                     `db:"uint64"`
+		Float32    float32                                    `db:"float32"`
+		Float64    float64                                    `db:"float64"`
+		Bytes      []byte                                     `db:"bytes"`
+		OptBytes   optional.Option[[]byte]                    `db:"opt_bytes"`
+		Nested     optional.Option[optional.Option[int64]]    `db:"nested"`
+		RawJSON    json.RawMessage                            `db:"raw_json"`
+		JSONColumn optional.JSONColumn[map[string]any]        `db:"json_column"`
+		NullString sql.NullString                             `db:"null_string"`
+		NullInt    sql.Null[int16]                            `db:"null_int"`
+		NullTime   sql.NullTime                               `db:"null_time"`
+		Array      optional.PGArray[string]                   `db:"array"`
+		OptArray   optional.Option[optional.PGArray[float64]] `db:"opt_array"`
+		Matrix     optional.PGArray[optional.PGArray[int32]]  `db:"matrix"`
+	}
+
+	statements, err := CreateTable[row]("types", Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "types" (
+	"bool" BOOLEAN NOT NULL,
+	"int8" SMALLINT NOT NULL,
+	"uint64" NUMERIC(20) NOT NULL,
+	"float32" REAL NOT NULL,
+	"float64" DOUBLE PRECISION NOT NULL,
+	"bytes" BYTEA NOT NULL,
+	"opt_bytes" BYTEA NULL,
+	"nested" BIGINT NULL,
+	"raw_json" JSONB NOT NULL,
+	"json_column" JSONB NULL,
+	"null_string" TEXT NULL,
+	"null_int" SMALLINT NULL,
+	"null_time" TIMESTAMP WITH TIME ZONE NULL,
+	"array" TEXT[] NOT NULL,
+	"opt_array" DOUBLE PRECISION[] NULL,
+	"matrix" INTEGER[][] NOT NULL
+)`, statements[0])
+
+	_, err = CreateTable[row]("types", SQLite)
+	assert.ErrorContains(t, err, "optional.PGArray is supported only for PostgreSQL")
+}
+
+func TestCreateTable_shouldRaiseError(t *testing.T) {
+	_, err := CreateTable[int]("t", SQLite)
+	assert.ErrorIs(t, err, ErrInvalidStruct)
+
+	_, err = CreateTable[struct{ A int }]("t", SQLite)
+	assert.ErrorIs(t, err, ErrNoColumns)
+
+	_, err = CreateTable[testUser]("t", Dialect(3))
+	assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	_, err = CreateTable[testUser]("t", Dialect(-1))
+	assert.ErrorIs(t, err, ErrUnsupportedDialect)
+
+	_, err = CreateTable[struct {
+		A map[string]int `db:"a"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, "unsupported type map[string]int")
+
+	statements, err := CreateTable[struct {
+		A map[string]int `db:"a" ddl:"type:JSON"`
+	}]("t", SQLite)
+	assert.NoError(t, err)
+	assert.Contains(t, statements[0], `"a" JSON NOT NULL`)
+
+	_, err = CreateTable[struct {
+		A optional.Option[int64] `db:"a" ddl:"pk"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, "must not be nullable")
+
+	_, err = CreateTable[struct {
+		A int64 `db:"a" ddl:"primary"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, `unknown ddl tag option "primary"`)
+
+	_, err = CreateTable[struct {
+		A int64 `db:"a" ddl:"autoIncrement"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, "requires pk")
+
+	_, err = CreateTable[struct {
+		A int64 `db:"a" ddl:"pk;autoIncrement"`
+		B int64 `db:"b" ddl:"pk"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, "composite primary key")
+
+	_, err = CreateTable[struct {
+		A int64 `db:"a"`
+		B int64 `db:"a"`
+	}]("t", SQLite)
+	assert.ErrorContains(t, err, `duplicate column "a"`)
+}
+
+func TestCreateTable_executeOnSQLite(t *testing.T) {
+	tmpfile, err := os.CreateTemp(os.TempDir(), "testdb")
+	assert.NoError(t, err)
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	statements, err := CreateTable[testUser]("users", SQLite)
+	assert.NoError(t, err)
+	for _, stmt := range statements {
+		_, err := db.Exec(stmt)
+		assert.NoError(t, err)
+	}
+
+	_, err = db.Exec(`INSERT INTO users(email, name, nickname, age) VALUES (?, ?, ?, ?)`, "foo@example.com", "foo", optional.None[string](), optional.Some[int32](20))
+	assert.NoError(t, err)
+
+	var (
+		id       int64
+		status   string
+		nickname optional.Option[string]
+	)
+	err = db.QueryRow(`SELECT id, status, nickname FROM users WHERE email = ?`, "foo@example.com").Scan(&id, &status, &nickname)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	assert.Equal(t, "active", status)
+	assert.True(t, nickname.IsNone())
+
+	// NOT NULL column
+	_, err = db.Exec(`INSERT INTO users(email, name) VALUES (?, ?)`, "bar@example.com", nil)
+	assert.Error(t, err)
+	// UNIQUE column
+	_, err = db.Exec(`INSERT INTO users(email, name) VALUES (?, ?)`, "foo@example.com", "foo2")
+	assert.Error(t, err)
+}
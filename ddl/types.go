@@ -0,0 +1,95 @@
+package ddl
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+	bytesType   = reflect.TypeOf([]byte{})
+)
+
+// columnTypes is the column types per dialect, in the order of SQLite, PostgreSQL and MySQL.
+type columnTypes [3]string
+
+var kindColumnTypes = map[reflect.Kind]columnTypes{
+	reflect.Bool:    {"BOOLEAN", "BOOLEAN", "BOOLEAN"},
+	reflect.Int8:    {"INTEGER", "SMALLINT", "TINYINT"},
+	reflect.Int16:   {"INTEGER", "SMALLINT", "SMALLINT"},
+	reflect.Int32:   {"INTEGER", "INTEGER", "INT"},
+	reflect.Int:     {"INTEGER", "BIGINT", "BIGINT"},
+	reflect.Int64:   {"INTEGER", "BIGINT", "BIGINT"},
+	reflect.Uint8:   {"INTEGER", "SMALLINT", "TINYINT UNSIGNED"},
+	reflect.Uint16:  {"INTEGER", "INTEGER", "SMALLINT UNSIGNED"},
+	reflect.Uint32:  {"INTEGER", "BIGINT", "INT UNSIGNED"},
+	reflect.Uint:    {"INTEGER", "NUMERIC(20)", "BIGINT UNSIGNED"},
+	reflect.Uint64:  {"INTEGER", "NUMERIC(20)", "BIGINT UNSIGNED"},
+	reflect.Float32: {"REAL", "REAL", "FLOAT"},
+	reflect.Float64: {"REAL", "DOUBLE PRECISION", "DOUBLE"},
+	reflect.String:  {"TEXT", "TEXT", "VARCHAR(255)"},
+}
+
+var (
+	bytesColumnTypes = columnTypes{"BLOB", "BYTEA", "BLOB"}
+	timeColumnTypes  = columnTypes{"DATETIME", "TIMESTAMP WITH TIME ZONE", "DATETIME(6)"}
+	jsonColumnTypes  = columnTypes{"TEXT", "JSONB", "JSON"}
+)
+
+// columnTypeOf returns the column type of the Go type and whether the column is nullable or not.
+// optional.Option[T], optional.JSONColumn[T] and the Null types of database/sql (e.g. sql.NullString and sql.Null[T]) are nullable.
+func columnTypeOf(typ reflect.Type, dialect Dialect) (string, bool, error) {
+	nullable := false
+	for {
+		if optiontype.Is(typ) {
+			typ = typ.Elem()
+			nullable = true
+			continue
+		}
+		if typ.PkgPath() == "database/sql" && strings.HasPrefix(typ.Name(), "Null") && typ.Kind() == reflect.Struct && typ.NumField() == 2 {
+			// e.g. sql.NullString{String string, Valid bool} and sql.Null[T]{V T, Valid bool}
+			typ = typ.Field(0).Type
+			nullable = true
+			continue
+		}
+		break
+	}
+
+	if optiontype.IsJSONColumn(typ) {
+		return jsonColumnTypes[dialect], true, nil
+	}
+	t, err := baseColumnTypeOf(typ, dialect)
+	return t, nullable, err
+}
+
+func baseColumnTypeOf(typ reflect.Type, dialect Dialect) (string, error) {
+	switch {
+	case typ == timeType:
+		return timeColumnTypes[dialect], nil
+	case typ == rawJSONType:
+		return jsonColumnTypes[dialect], nil
+	case typ == bytesType || (typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8):
+		return bytesColumnTypes[dialect], nil
+	case optiontype.IsPGArray(typ):
+		if dialect != Postgres {
+			return "", fmt.Errorf("optional.PGArray is supported only for PostgreSQL")
+		}
+		// the element is Option[T], and the nested PGArray makes the multi-dimensional array
+		elem, _, err := columnTypeOf(typ.Elem(), dialect)
+		if err != nil {
+			return "", err
+		}
+		return elem + "[]", nil
+	}
+
+	if t, ok := kindColumnTypes[typ.Kind()]; ok {
+		return t[dialect], nil
+	}
+	return "", fmt.Errorf("unsupported type %s; please specify the column type by `ddl:\"type:...\"` struct tag", typ)
+}
@@ -0,0 +1,133 @@
+// Package env provides the functions to read environment variables as optional.Option values.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/moznion/go-optional"
+	"github.com/moznion/go-optional/internal/optiontype"
+	"github.com/moznion/go-optional/internal/textparse"
+)
+
+var (
+	// ErrInvalidDestination represents the error that is raised when the destination of Load is not a non-nil pointer to a struct.
+	ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+)
+
+// ParseError represents the error that is raised when the value of an environment variable can't be parsed.
+type ParseError struct {
+	// Name is the name of the environment variable.
+	Name string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse environment variable %s: %s", e.Name, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Lookup retrieves the value of the environment variable named by the name and parses that as T.
+// If the variable is not present in the environment, this returns (None, nil). If the variable is present and parsed successfully, this returns (Some[T], nil).
+// Else if the value can't be parsed, this returns (None, *ParseError).
+//
+// The value is parsed by encoding.TextUnmarshaler if T implements that, otherwise this parses the value according to the kind of T (string, bool, integer, float and time.Duration).
+func Lookup[T any](name string) (optional.Option[T], error) {
+	text, ok := os.LookupEnv(name)
+	if !ok {
+		return optional.None[T](), nil
+	}
+
+	var v T
+	err := textparse.Parse(text, &v)
+	if err != nil {
+		return optional.None[T](), &ParseError{Name: name, Err: err}
+	}
+	return optional.Some(v), nil
+}
+
+// Load fills the Option fields of the struct that is pointed by dst from the environment variables.
+// This is equivalent to LoadWithPrefix(dst, "").
+func Load(dst any) error {
+	return LoadWithPrefix(dst, "")
+}
+
+// LoadWithPrefix fills the Option fields of the struct that is pointed by dst from the environment variables.
+//
+// The name of the environment variable is specified by `env:"NAME"` struct tag, and the prefix is prepended to that name.
+// If the variable is present, the field becomes Some with the parsed value (see also Lookup). If the variable is not present, the field is left untouched.
+// The tagged field must be an optional.Option type.
+//
+// The nested struct fields (including embedded ones) are loaded recursively; `envPrefix:"PREFIX_"` struct tag on the nested struct field appends the prefix for the fields of that struct.
+// This loads all the fields even if some variables can't be parsed, and then returns the joined error of *ParseError for each failed variable.
+func LoadWithPrefix(dst any, prefix string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	var errs []error
+	err := loadStruct(rv.Elem(), prefix, &errs)
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+func loadStruct(rv reflect.Value, prefix string, errs *[]error) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		fieldValue := rv.Field(i)
+
+		name, hasTag := field.Tag.Lookup("env")
+		if hasTag && field.IsExported() {
+			if name == "" || name == "-" {
+				continue
+			}
+			if !optiontype.Is(field.Type) {
+				return fmt.Errorf("field %s has env tag but the type %s is not optional.Option", field.Name, field.Type)
+			}
+			if !textparse.IsSupported(field.Type.Elem()) {
+				return fmt.Errorf("field %s has unsupported type %s", field.Name, field.Type)
+			}
+			err := loadOption(fieldValue, prefix+name)
+			if err != nil {
+				*errs = append(*errs, err)
+			}
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && !optiontype.Is(field.Type) {
+			err := loadStruct(fieldValue, prefix+field.Tag.Get("envPrefix"), errs)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func loadOption(option reflect.Value, name string) error {
+	text, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	some := optiontype.Some(option.Type())
+	err := textparse.ParseValue(text, optiontype.Elem(some))
+	if err != nil {
+		return &ParseError{Name: name, Err: err}
+	}
+	option.Set(some)
+	return nil
+}
@@ -0,0 +1,137 @@
+package env
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	t.Setenv("GO_OPTIONAL_TEST_INT", "123")
+	t.Setenv("GO_OPTIONAL_TEST_EMPTY", "")
+	t.Setenv("GO_OPTIONAL_TEST_IP", "192.0.2.1")
+
+	i, err := Lookup[int]("GO_OPTIONAL_TEST_INT")
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[int](123), i)
+
+	s, err := Lookup[string]("GO_OPTIONAL_TEST_EMPTY")
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[string](""), s)
+
+	ip, err := Lookup[net.IP]("GO_OPTIONAL_TEST_IP")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", ip.Unwrap().String())
+
+	unset, err := Lookup[int]("GO_OPTIONAL_TEST_UNSET")
+	assert.NoError(t, err)
+	assert.True(t, unset.IsNone())
+}
+
+func TestLookup_shouldReturnParseError(t *testing.T) {
+	t.Setenv("GO_OPTIONAL_TEST_INT", "abc")
+
+	i, err := Lookup[int]("GO_OPTIONAL_TEST_INT")
+	assert.True(t, i.IsNone())
+
+	var parseErr *ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "GO_OPTIONAL_TEST_INT", parseErr.Name)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+type testDBConfig struct {
+	Host optional.Option[string] `env:"HOST"`
+	Port optional.Option[int]    `env:"PORT"`
+}
+
+type testCommonConfig struct {
+	Debug optional.Option[bool] `env:"DEBUG"`
+}
+
+type testConfig struct {
+	testCommonConfig
+	Timeout  optional.Option[time.Duration] `env:"TIMEOUT"`
+	Name     optional.Option[string]        `env:"NAME"`
+	DB       testDBConfig                   `envPrefix:"DB_"`
+	Ignored  optional.Option[string]        `env:"-"`
+	Untagged string
+	private  optional.Option[string] `env:"PRIVATE"` //nolint:unused
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+	t.Setenv("TIMEOUT", "3s")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("-", "ignored")
+	t.Setenv("PRIVATE", "private")
+
+	cfg := testConfig{
+		Name: optional.Some[string]("default"),
+	}
+	err := Load(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[bool](true), cfg.Debug)
+	assert.Equal(t, optional.Some[time.Duration](3*time.Second), cfg.Timeout)
+	assert.Equal(t, optional.Some[string]("default"), cfg.Name)
+	assert.Equal(t, optional.Some[string]("localhost"), cfg.DB.Host)
+	assert.True(t, cfg.DB.Port.IsNone())
+	assert.True(t, cfg.Ignored.IsNone())
+	assert.True(t, cfg.private.IsNone())
+}
+
+func TestLoadWithPrefix(t *testing.T) {
+	t.Setenv("APP_NAME", "app")
+	t.Setenv("APP_DB_PORT", "5432")
+	t.Setenv("NAME", "no-prefix")
+
+	var cfg testConfig
+	err := LoadWithPrefix(&cfg, "APP_")
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[string]("app"), cfg.Name)
+	assert.Equal(t, optional.Some[int](5432), cfg.DB.Port)
+	assert.True(t, cfg.Debug.IsNone())
+}
+
+func TestLoad_shouldCollectParseErrors(t *testing.T) {
+	t.Setenv("DEBUG", "yes?")
+	t.Setenv("TIMEOUT", "3 seconds")
+	t.Setenv("NAME", "foo")
+	t.Setenv("DB_PORT", "port")
+
+	var cfg testConfig
+	err := Load(&cfg)
+	assert.Error(t, err)
+	assert.Equal(t, optional.Some[string]("foo"), cfg.Name)
+
+	var names []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var parseErr *ParseError
+		assert.True(t, errors.As(e, &parseErr))
+		names = append(names, parseErr.Name)
+	}
+	assert.Equal(t, []string{"DEBUG", "TIMEOUT", "DB_PORT"}, names)
+}
+
+func TestLoad_shouldReturnErrorForInvalidDestination(t *testing.T) {
+	var cfg testConfig
+	assert.ErrorIs(t, Load(cfg), ErrInvalidDestination)
+	assert.ErrorIs(t, Load((*testConfig)(nil)), ErrInvalidDestination)
+	i := 0
+	assert.ErrorIs(t, Load(&i), ErrInvalidDestination)
+
+	type nonOption struct {
+		Name string `env:"NAME"`
+	}
+	assert.Error(t, Load(&nonOption{}))
+
+	type unsupported struct {
+		Names optional.Option[[]string] `env:"NAMES"`
+	}
+	assert.Error(t, Load(&unsupported{}))
+}
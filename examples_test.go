@@ -581,8 +581,8 @@ func ExampleOption_Or() {
 	fmt.Printf("%s\n", none.Or(fallback))
 
 	// Output:
-	// Some[actual]
-	// Some[fallback]
+	// actual
+	// fallback
 }
 
 func ExampleOption_OrElse() {
@@ -595,6 +595,6 @@ func ExampleOption_OrElse() {
 	fmt.Printf("%s\n", none.OrElse(fallbackFunc))
 
 	// Output:
-	// Some[actual]
-	// Some[fallback]
+	// actual
+	// fallback
 }
@@ -0,0 +1,111 @@
+package optional
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/moznion/go-optional/internal/textparse"
+)
+
+// FlagValue is an adapter that makes *Option[T] work as a command-line flag value.
+// This implements flag.Value and flag.Getter, and also has `Type()` method so this can be used as spf13/pflag's Value (i.e. cobra's flag).
+//
+// The Option stays None until the flag is set, so this can tell "the flag is not passed" apart from "the flag is passed with the zero value".
+// The value is parsed by encoding.TextUnmarshaler if T implements that, otherwise this parses the value according to the kind of T (string, bool, integer, float and time.Duration).
+// If T is a slice type (e.g. Option[[]string]), each occurrence of the repeated flag appends the parsed element to the slice.
+type FlagValue[T any] struct {
+	option *Option[T]
+}
+
+// NewFlagValue makes a FlagValue that sets the flag value into the given Option.
+// This doesn't touch the current value of the Option.
+func NewFlagValue[T any](option *Option[T]) *FlagValue[T] {
+	return &FlagValue[T]{
+		option: option,
+	}
+}
+
+// FlagVar defines a flag with the specified name and usage string on the given FlagSet.
+// The given Option is reset to None, and that becomes Some when the flag is passed.
+func FlagVar[T any](fs *flag.FlagSet, option *Option[T], name string, usage string) {
+	*option = None[T]()
+	fs.Var(NewFlagValue(option), name, usage)
+}
+
+// Set parses the given flag value and stores that into the Option.
+// This method is required from flag.Value interface.
+func (f *FlagValue[T]) Set(s string) error {
+	if isFlagSliceType[T]() {
+		current := f.option.Unwrap()
+		rv := reflect.ValueOf(&current).Elem()
+		elem := reflect.New(rv.Type().Elem()).Elem()
+		err := textparse.ParseValue(s, elem)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.Append(rv, elem))
+		*f.option = Some(current)
+		return nil
+	}
+
+	var v T
+	err := textparse.Parse(s, &v)
+	if err != nil {
+		return err
+	}
+	*f.option = Some(v)
+	return nil
+}
+
+// String returns the string representation of the contained value. If the Option is None, this returns an empty string.
+// This method is required from flag.Value interface.
+func (f *FlagValue[T]) String() string {
+	if f == nil || f.option == nil || f.option.IsNone() {
+		return ""
+	}
+
+	v := f.option.Unwrap()
+	if isFlagSliceType[T]() {
+		rv := reflect.ValueOf(v)
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// Get returns the Option value.
+// This method is required from flag.Getter interface.
+func (f *FlagValue[T]) Get() any {
+	return *f.option
+}
+
+// Type returns the name of the value type, e.g. "int", "string" and "stringSlice".
+// This method is required from spf13/pflag.Value interface.
+func (f *FlagValue[T]) Type() string {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if isFlagSliceType[T]() {
+		return typ.Elem().String() + "Slice"
+	}
+	return typ.String()
+}
+
+// IsBoolFlag reports whether the flag can be passed without the value (e.g. `-verbose`), i.e. whether T is a boolean type.
+// This method is referred by flag package.
+func (f *FlagValue[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
+
+// isFlagSliceType returns whether T is dealt with as the repeatable slice type or not.
+// A slice type that implements encoding.TextUnmarshaler (e.g. net.IP) is dealt with as a single value.
+func isFlagSliceType[T any]() bool {
+	if _, ok := any((*T)(nil)).(encoding.TextUnmarshaler); ok {
+		return false
+	}
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Slice
+}
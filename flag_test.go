@@ -0,0 +1,131 @@
+package optional
+
+import (
+	"flag"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestFlagVar(t *testing.T) {
+	var (
+		name    Option[string]
+		count   Option[int]
+		timeout Option[time.Duration]
+		verbose Option[bool]
+		ip      Option[net.IP]
+	)
+
+	fs := newTestFlagSet()
+	FlagVar(fs, &name, "name", "name")
+	FlagVar(fs, &count, "count", "count")
+	FlagVar(fs, &timeout, "timeout", "timeout")
+	FlagVar(fs, &verbose, "verbose", "verbose")
+	FlagVar(fs, &ip, "ip", "ip")
+
+	err := fs.Parse([]string{"-count", "0", "-verbose", "-timeout=1s", "-ip", "192.0.2.1"})
+	assert.NoError(t, err)
+
+	assert.True(t, name.IsNone())
+	assert.Equal(t, Some[int](0), count)
+	assert.Equal(t, Some[time.Duration](time.Second), timeout)
+	assert.Equal(t, Some[bool](true), verbose)
+	assert.Equal(t, "192.0.2.1", ip.Unwrap().String())
+}
+
+func TestFlagVar_shouldResetOptionToNone(t *testing.T) {
+	name := Some[string]("foo")
+
+	fs := newTestFlagSet()
+	FlagVar(fs, &name, "name", "name")
+	err := fs.Parse([]string{})
+	assert.NoError(t, err)
+	assert.True(t, name.IsNone())
+}
+
+func TestFlagVar_repeatedFlagsIntoSlice(t *testing.T) {
+	var (
+		tags  Option[[]string]
+		ports Option[[]int]
+	)
+
+	fs := newTestFlagSet()
+	FlagVar(fs, &tags, "tag", "tag")
+	FlagVar(fs, &ports, "port", "port")
+
+	err := fs.Parse([]string{"-tag", "a", "-port", "80", "-tag", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, Some[[]string]([]string{"a", "b"}), tags)
+	assert.Equal(t, Some[[]int]([]int{80}), ports)
+
+	assert.Equal(t, "[a,b]", fs.Lookup("tag").Value.String())
+}
+
+func TestFlagVar_shouldReturnErrorWhenValueIsInvalid(t *testing.T) {
+	var (
+		count Option[int]
+		ports Option[[]int]
+	)
+
+	fs := newTestFlagSet()
+	FlagVar(fs, &count, "count", "count")
+	FlagVar(fs, &ports, "port", "port")
+
+	assert.Error(t, fs.Parse([]string{"-count", "abc"}))
+	assert.True(t, count.IsNone())
+
+	assert.Error(t, fs.Parse([]string{"-port", "abc"}))
+	assert.True(t, ports.IsNone())
+}
+
+func TestFlagValue(t *testing.T) {
+	var count Option[int]
+	v := NewFlagValue(&count)
+	assert.Equal(t, "", v.String())
+	assert.Equal(t, None[int](), v.Get())
+	assert.Equal(t, "int", v.Type())
+	assert.False(t, v.IsBoolFlag())
+
+	assert.NoError(t, v.Set("42"))
+	assert.Equal(t, "42", v.String())
+	assert.Equal(t, Some[int](42), v.Get())
+
+	var tags Option[[]string]
+	assert.Equal(t, "stringSlice", NewFlagValue(&tags).Type())
+
+	var verbose Option[bool]
+	assert.True(t, NewFlagValue(&verbose).IsBoolFlag())
+
+	var ip Option[net.IP]
+	assert.Equal(t, "net.IP", NewFlagValue(&ip).Type())
+}
+
+func TestFlagValue_InterfaceSatisfaction(t *testing.T) {
+	var o Option[int]
+	var v flag.Getter = NewFlagValue(&o)
+	assert.NotNil(t, v)
+
+	var pflagValue interface {
+		flag.Value
+		Type() string
+	} = NewFlagValue(&o)
+	assert.NotNil(t, pflagValue)
+}
+
+func TestFlagSet_PrintDefaults(t *testing.T) {
+	var count Option[int]
+	fs := newTestFlagSet()
+	FlagVar(fs, &count, "count", "the `number` of items")
+	assert.NotPanics(t, func() {
+		fs.PrintDefaults()
+	})
+}
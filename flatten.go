@@ -0,0 +1,61 @@
+package optional
+
+// Flatten removes one level of the nesting from the nested Option value.
+// If the outer or the inner Option value is None, this returns None.
+func Flatten[T any](option Option[Option[T]]) Option[T] {
+	if option.IsNone() {
+		return None[T]()
+	}
+	return option[value]
+}
+
+// TransposeSlice converts the Option of the slice into the slice of the Option values.
+// If given Option value is Some, this returns the slice that has each element as Some. Otherwise, this returns an empty slice.
+// The conversion in the opposite direction is Sequence.
+func TransposeSlice[T any](option Option[[]T]) []Option[T] {
+	if option.IsNone() {
+		return []Option[T]{}
+	}
+
+	vs := option[value]
+	opts := make([]Option[T], len(vs))
+	for i, v := range vs {
+		opts[i] = Some(v)
+	}
+	return opts
+}
+
+// TransposeError converts the Option of the pair of a value and an error into the Option value with an error.
+// If given Option value is None, this returns (None, nil). Else if the pair has an error then this returns (None, error).
+// Unless of them, this returns (Some[T], nil).
+func TransposeError[T any](option Option[Pair[T, error]]) (Option[T], error) {
+	if option.IsNone() {
+		return None[T](), nil
+	}
+
+	pair := option[value]
+	if pair.Value2 != nil {
+		return None[T](), pair.Value2
+	}
+	return Some(pair.Value1), nil
+}
+
+// PairWithError converts the Option value with an error into the Option of the pair of the value and the error; this is the opposite conversion of TransposeError.
+// If given error is not nil, this returns Some with the pair of the zero value and the error. Else if given Option value is None, this returns None.
+// Unless of them, this returns Some with the pair of the value and nil error.
+func PairWithError[T any](option Option[T], err error) Option[Pair[T, error]] {
+	if err != nil {
+		var zeroValue T
+		return Some(Pair[T, error]{
+			Value1: zeroValue,
+			Value2: err,
+		})
+	}
+	if option.IsNone() {
+		return None[Pair[T, error]]()
+	}
+	return Some(Pair[T, error]{
+		Value1: option[value],
+		Value2: nil,
+	})
+}
@@ -0,0 +1,62 @@
+package optional
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	assert.Equal(t, Some[int](123), Flatten(Some(Some[int](123))))
+	assert.True(t, Flatten(Some(None[int]())).IsNone())
+	assert.True(t, Flatten(None[Option[int]]()).IsNone())
+
+	assert.Equal(t, Some[int](123), Flatten(Flatten(Some(Some(Some[int](123))))))
+}
+
+func TestTransposeSlice(t *testing.T) {
+	assert.Equal(t, []Option[int]{Some[int](1), Some[int](2)}, TransposeSlice(Some([]int{1, 2})))
+	assert.Equal(t, []Option[int]{}, TransposeSlice(Some([]int{})))
+	assert.Equal(t, []Option[int]{}, TransposeSlice(None[[]int]()))
+
+	assert.Equal(t, Some([]int{1, 2}), Sequence(TransposeSlice(Some([]int{1, 2}))))
+}
+
+func TestTransposeError(t *testing.T) {
+	v, err := TransposeError(Some(Pair[int, error]{Value1: 123}))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](123), v)
+
+	v, err = TransposeError(None[Pair[int, error]]())
+	assert.NoError(t, err)
+	assert.True(t, v.IsNone())
+
+	errFoo := errors.New("foo")
+	v, err = TransposeError(Some(Pair[int, error]{Value1: 123, Value2: errFoo}))
+	assert.ErrorIs(t, err, errFoo)
+	assert.True(t, v.IsNone())
+
+	// Option[(T, error)] that is built by Map
+	atoi := func(s string) Pair[int, error] {
+		i, err := strconv.Atoi(s)
+		return Pair[int, error]{Value1: i, Value2: err}
+	}
+	v, err = TransposeError(Map(Some[string]("foo"), atoi))
+	assert.Error(t, err)
+	assert.True(t, v.IsNone())
+}
+
+func TestPairWithError(t *testing.T) {
+	assert.Equal(t, Some(Pair[int, error]{Value1: 123}), PairWithError(Some[int](123), nil))
+	assert.True(t, PairWithError(None[int](), nil).IsNone())
+
+	errFoo := errors.New("foo")
+	assert.Equal(t, Some(Pair[int, error]{Value2: errFoo}), PairWithError(Some[int](123), errFoo))
+	assert.Equal(t, Some(Pair[int, error]{Value2: errFoo}), PairWithError(None[int](), errFoo))
+
+	v, err := TransposeError(PairWithError(Some[int](123), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](123), v)
+}
@@ -0,0 +1,61 @@
+package optional
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFmtNullToken is the token that is scanned as None by FmtScanner when no null token is specified.
+const DefaultFmtNullToken = "NULL"
+
+// FmtScanner is an adapter that makes *Option[T] work with fmt.Scan family functions (e.g. fmt.Sscan, fmt.Fscan and fmt.Sscanf).
+// This is necessary because Option[T] can't implement fmt.Scanner directly; the `Scan` method name is already occupied by database/sql.Scanner.
+type FmtScanner[T any] struct {
+	option     *Option[T]
+	nullTokens []string
+}
+
+// AsFmtScanner makes a FmtScanner that scans a value into the given Option.
+// If the scanned token is equal to one of nullTokens, the Option becomes None. Otherwise, the token is scanned according to T's scanning rules and the Option becomes Some.
+// If nullTokens is not given, DefaultFmtNullToken is used.
+func AsFmtScanner[T any](option *Option[T], nullTokens ...string) *FmtScanner[T] {
+	if len(nullTokens) <= 0 {
+		nullTokens = []string{DefaultFmtNullToken}
+	}
+	return &FmtScanner[T]{
+		option:     option,
+		nullTokens: nullTokens,
+	}
+}
+
+// Scan reads a space-delimited token and stores it into the Option.
+// This method is required from fmt.Scanner interface.
+func (s *FmtScanner[T]) Scan(state fmt.ScanState, verb rune) error {
+	token, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	if len(token) <= 0 {
+		return fmt.Errorf("no token to scan into Option")
+	}
+
+	for _, nullToken := range s.nullTokens {
+		if string(token) == nullToken {
+			*s.option = None[T]()
+			return nil
+		}
+	}
+
+	var v T
+	r := strings.NewReader(string(token))
+	_, err = fmt.Fscanf(r, "%"+string(verb), &v)
+	if err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("unexpected trailing characters in token %q", token)
+	}
+	*s.option = Some(v)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package optional
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFmtScanner_Sscan(t *testing.T) {
+	var (
+		id    Option[int]
+		name  Option[string]
+		score Option[float64]
+	)
+
+	n, err := fmt.Sscan("1 foo NULL", AsFmtScanner(&id), AsFmtScanner(&name), AsFmtScanner(&score))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, Some[int](1), id)
+	assert.Equal(t, Some[string]("foo"), name)
+	assert.True(t, score.IsNone())
+
+	n, err = fmt.Sscan("NULL NULL 1.5", AsFmtScanner(&id), AsFmtScanner(&name), AsFmtScanner(&score))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.True(t, id.IsNone())
+	assert.True(t, name.IsNone())
+	assert.Equal(t, Some[float64](1.5), score)
+}
+
+func TestFmtScanner_Fscan_withCustomNullTokens(t *testing.T) {
+	input := "1\t-\n-\tbar\n3\tnull\n"
+	r := strings.NewReader(input)
+
+	var got []Pair[Option[int], Option[string]]
+	for {
+		var (
+			id   Option[int]
+			name Option[string]
+		)
+		_, err := fmt.Fscanln(r, AsFmtScanner(&id, "-"), AsFmtScanner(&name, "-", "null"))
+		if err != nil {
+			break
+		}
+		got = append(got, Pair[Option[int], Option[string]]{Value1: id, Value2: name})
+	}
+
+	assert.Equal(t, []Pair[Option[int], Option[string]]{
+		{Value1: Some[int](1), Value2: None[string]()},
+		{Value1: None[int](), Value2: Some[string]("bar")},
+		{Value1: Some[int](3), Value2: None[string]()},
+	}, got)
+}
+
+func TestFmtScanner_Sscanf(t *testing.T) {
+	var (
+		hex  Option[int]
+		flag Option[bool]
+	)
+	_, err := fmt.Sscanf("ff true", "%x %t", AsFmtScanner(&hex), AsFmtScanner(&flag))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](255), hex)
+	assert.Equal(t, Some[bool](true), flag)
+}
+
+func TestFmtScanner_shouldReturnErrorWhenTokenIsInvalid(t *testing.T) {
+	o := Some[int](123)
+
+	_, err := fmt.Sscan("abc", AsFmtScanner(&o))
+	assert.Error(t, err)
+	assert.Equal(t, Some[int](123), o)
+
+	_, err = fmt.Sscan("12abc", AsFmtScanner(&o))
+	assert.Error(t, err)
+	assert.Equal(t, Some[int](123), o)
+
+	_, err = fmt.Sscan("", AsFmtScanner(&o))
+	assert.Error(t, err)
+}
+
+func TestFmtScanner_ScannerInterfaceSatisfaction(t *testing.T) {
+	var o Option[int]
+	var s fmt.Scanner = AsFmtScanner(&o)
+	assert.NotNil(t, s)
+}
@@ -0,0 +1,46 @@
+// Package optiontype provides the reflection helpers for optional.Option types.
+package optiontype
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/moznion/go-optional"
+)
+
+var pkgPath = reflect.TypeOf(optional.Option[any]{}).PkgPath()
+
+// Is returns whether the type is optional.Option[T] or not.
+func Is(typ reflect.Type) bool {
+	return isGenericTypeOf(typ, "Option[")
+}
+
+// IsJSONColumn returns whether the type is optional.JSONColumn[T] or not.
+func IsJSONColumn(typ reflect.Type) bool {
+	return isGenericTypeOf(typ, "JSONColumn[")
+}
+
+// IsPGArray returns whether the type is optional.PGArray[T] or not.
+func IsPGArray(typ reflect.Type) bool {
+	return isGenericTypeOf(typ, "PGArray[")
+}
+
+// isGenericTypeOf returns whether the type is the instance of the generic type of optional package, which is named with the given prefix (e.g. `Option[`).
+func isGenericTypeOf(typ reflect.Type, namePrefix string) bool {
+	return typ.PkgPath() == pkgPath && strings.HasPrefix(typ.Name(), namePrefix)
+}
+
+// Some makes the reflect.Value of Some[T] of the given Option type. The contained value is the zero value and settable through Elem().
+func Some(typ reflect.Type) reflect.Value {
+	return reflect.MakeSlice(typ, 1, 1)
+}
+
+// Elem returns the contained value of the Option value. The Option value must be Some.
+func Elem(option reflect.Value) reflect.Value {
+	return option.Index(0)
+}
+
+// IsSome returns whether the Option value is Some or not.
+func IsSome(option reflect.Value) bool {
+	return option.Len() > 0
+}
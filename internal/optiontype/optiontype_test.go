@@ -0,0 +1,36 @@
+package optiontype
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type Option[T any] []T
+
+func TestIs(t *testing.T) {
+	assert.True(t, Is(reflect.TypeOf(optional.Option[int]{})))
+	assert.True(t, Is(reflect.TypeOf(optional.Option[optional.Option[string]]{})))
+	assert.False(t, Is(reflect.TypeOf([]int{})))
+	assert.False(t, Is(reflect.TypeOf(Option[int]{})))
+	assert.False(t, Is(reflect.TypeOf(optional.Pair[int, int]{})))
+}
+
+func TestIsJSONColumnAndIsPGArray(t *testing.T) {
+	assert.True(t, IsJSONColumn(reflect.TypeOf(optional.JSONColumn[map[string]any]{})))
+	assert.False(t, IsJSONColumn(reflect.TypeOf(optional.Option[int]{})))
+	assert.True(t, IsPGArray(reflect.TypeOf(optional.PGArray[int]{})))
+	assert.False(t, IsPGArray(reflect.TypeOf(optional.Option[int]{})))
+	assert.False(t, Is(reflect.TypeOf(optional.PGArray[int]{})))
+}
+
+func TestSomeAndElem(t *testing.T) {
+	some := Some(reflect.TypeOf(optional.Option[int]{}))
+	assert.True(t, IsSome(some))
+	Elem(some).SetInt(42)
+	assert.Equal(t, optional.Some[int](42), some.Interface())
+
+	assert.False(t, IsSome(reflect.ValueOf(optional.None[int]())))
+}
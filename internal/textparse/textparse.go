@@ -0,0 +1,97 @@
+// Package textparse provides the function to parse a text into a value according to the type of the value.
+// This is shared by the features that read values from the textual sources, such as command-line flags and environment variables.
+package textparse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// IsSupported returns whether the given type can be parsed by Parse or not.
+func IsSupported(typ reflect.Type) bool {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) || typ == durationType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Parse parses the text and sets the result to the value that is pointed by ptr.
+// If the pointed type implements encoding.TextUnmarshaler, this uses that. Else if the type is time.Duration, this uses time.ParseDuration().
+// Otherwise, this parses the text according to the kind of the type (i.e. string, bool, integer and float kinds); other kinds are unsupported.
+func Parse(text string, ptr any) error {
+	if u, ok := ptr.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("the destination must be a non-nil pointer but got %T", ptr)
+	}
+	return ParseValue(text, rv.Elem())
+}
+
+// ParseValue parses the text and sets the result to the given settable value.
+// The parsing rule is the same as Parse.
+func ParseValue(text string, v reflect.Value) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(text))
+		}
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type to parse: %s", v.Type())
+	}
+	return nil
+}
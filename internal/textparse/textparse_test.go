@@ -0,0 +1,75 @@
+package textparse
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type level int
+
+func TestParse(t *testing.T) {
+	{
+		var v string
+		assert.NoError(t, Parse("foo", &v))
+		assert.Equal(t, "foo", v)
+	}
+	{
+		var v bool
+		assert.NoError(t, Parse("true", &v))
+		assert.True(t, v)
+	}
+	{
+		var v int8
+		assert.NoError(t, Parse("-0x10", &v))
+		assert.Equal(t, int8(-16), v)
+		assert.Error(t, Parse("128", &v))
+	}
+	{
+		var v level
+		assert.NoError(t, Parse("3", &v))
+		assert.Equal(t, level(3), v)
+	}
+	{
+		var v uint16
+		assert.NoError(t, Parse("65535", &v))
+		assert.Equal(t, uint16(65535), v)
+		assert.Error(t, Parse("-1", &v))
+	}
+	{
+		var v float32
+		assert.NoError(t, Parse("1.5", &v))
+		assert.Equal(t, float32(1.5), v)
+	}
+	{
+		var v time.Duration
+		assert.NoError(t, Parse("1m30s", &v))
+		assert.Equal(t, 90*time.Second, v)
+	}
+	{
+		var v net.IP
+		assert.NoError(t, Parse("192.0.2.1", &v))
+		assert.Equal(t, "192.0.2.1", v.String())
+		assert.Error(t, Parse("invalid", &v))
+	}
+	{
+		var v []string
+		assert.Error(t, Parse("foo", &v))
+	}
+	{
+		var v int
+		assert.Error(t, Parse("1", v))
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported(reflect.TypeOf("")))
+	assert.True(t, IsSupported(reflect.TypeOf(level(0))))
+	assert.True(t, IsSupported(reflect.TypeOf(time.Second)))
+	assert.True(t, IsSupported(reflect.TypeOf(net.IP{})))
+	assert.False(t, IsSupported(reflect.TypeOf([]string{})))
+	assert.False(t, IsSupported(reflect.TypeOf(struct{}{})))
+}
@@ -0,0 +1,72 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// JSONColumn is an Option type for the database column that stores a JSON document (e.g. JSON/JSONB column, or TEXT column that contains JSON).
+// This implements database/sql.Scanner and database/sql/driver.Valuer: SQL NULL is scanned as None, and the JSON text or bytes are unmarshaled into Some[T].
+// On the other hand, None is written as SQL NULL and Some[T] is written as the marshaled JSON string.
+//
+// JSONColumn[T] and Option[T] can be converted to each other by the type conversion, e.g. `JSONColumn[T](opt)` and `Option[T](col)`, or by JSONColumnOf() and JSONColumn#Option().
+type JSONColumn[T any] Option[T]
+
+// JSONColumnOf converts the Option value into JSONColumn.
+func JSONColumnOf[T any](option Option[T]) JSONColumn[T] {
+	return JSONColumn[T](option)
+}
+
+// Option converts the JSONColumn value into Option.
+func (c JSONColumn[T]) Option() Option[T] {
+	return Option[T](c)
+}
+
+// Scan assigns a JSON value from a database driver.
+// If the src is nil or JSON null, the JSONColumn becomes None.
+// This method is required from database/sql.Scanner interface.
+func (c *JSONColumn[T]) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		data = []byte(s)
+	case []byte:
+		data = s
+	default:
+		return fmt.Errorf("unsupported type to scan JSON column: %T", src)
+	}
+
+	var o Option[T]
+	err := o.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*c = JSONColumn[T](o)
+	return nil
+}
+
+// Value returns a driver Value as the marshaled JSON string. If the JSONColumn is None, this returns nil.
+// This method is required from database/sql/driver.Valuer interface.
+func (c JSONColumn[T]) Value() (driver.Value, error) {
+	if c.Option().IsNone() {
+		return nil, nil
+	}
+	marshal, err := c.Option().MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(marshal), nil
+}
+
+// MarshalJSON marshals the JSONColumn in the same manner as Option.
+func (c JSONColumn[T]) MarshalJSON() ([]byte, error) {
+	return c.Option().MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals the JSON data in the same manner as Option.
+func (c *JSONColumn[T]) UnmarshalJSON(data []byte) error {
+	return (*Option[T])(c).UnmarshalJSON(data)
+}
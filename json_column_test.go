@@ -0,0 +1,129 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonColumnTestDocument struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+func TestJSONColumn_Scan(t *testing.T) {
+	var c JSONColumn[jsonColumnTestDocument]
+
+	err := c.Scan(`{"title":"foo","tags":["a"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, Some[jsonColumnTestDocument](jsonColumnTestDocument{Title: "foo", Tags: []string{"a"}}), c.Option())
+
+	err = c.Scan([]byte(`{"title":"bar"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[jsonColumnTestDocument](jsonColumnTestDocument{Title: "bar"}), c.Option())
+
+	err = c.Scan(nil)
+	assert.NoError(t, err)
+	assert.True(t, c.Option().IsNone())
+
+	err = c.Scan("null")
+	assert.NoError(t, err)
+	assert.True(t, c.Option().IsNone())
+
+	err = c.Scan(`{"title":`)
+	assert.Error(t, err)
+
+	err = c.Scan(int64(1))
+	assert.Error(t, err)
+}
+
+func TestJSONColumn_Value(t *testing.T) {
+	v, err := JSONColumnOf(Some[[]int]([]int{1, 2})).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", v)
+
+	v, err = JSONColumnOf(None[[]int]()).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = JSONColumnOf(Some[chan int](make(chan int))).Value()
+	assert.Error(t, err)
+}
+
+func TestJSONColumn_Conversion(t *testing.T) {
+	o := Some[string]("foo")
+	assert.Equal(t, o, JSONColumnOf(o).Option())
+	assert.Equal(t, o, Option[string](JSONColumn[string](o)))
+	assert.True(t, JSONColumnOf(None[string]()).Option().IsNone())
+}
+
+func TestJSONColumn_SerdeJSON(t *testing.T) {
+	type JSONStruct struct {
+		Doc JSONColumn[jsonColumnTestDocument] `json:"doc"`
+	}
+
+	marshal, err := json.Marshal(JSONStruct{Doc: JSONColumnOf(Some[jsonColumnTestDocument](jsonColumnTestDocument{Title: "foo"}))})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"doc":{"title":"foo","tags":null}}`, string(marshal))
+
+	var unmarshaled JSONStruct
+	err = json.Unmarshal([]byte(`{"doc":null}`), &unmarshaled)
+	assert.NoError(t, err)
+	assert.True(t, unmarshaled.Doc.Option().IsNone())
+}
+
+func TestJSONColumn_InterfaceSatisfaction(t *testing.T) {
+	c := JSONColumnOf(Some[int](1))
+	var s sql.Scanner = &c
+	var v driver.Valuer = c
+	assert.NotNil(t, s)
+	assert.NotNil(t, v)
+}
+
+func TestJSONColumn_SQL(t *testing.T) {
+	tmpfile, err := os.CreateTemp(os.TempDir(), "testdb")
+	assert.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	_, err = db.Exec("CREATE TABLE test_table (id INTEGER NOT NULL PRIMARY KEY, doc JSON);")
+	assert.NoError(t, err)
+
+	doc := jsonColumnTestDocument{Title: "foo", Tags: []string{"a", "b"}}
+	_, err = db.Exec("INSERT INTO test_table(id, doc) VALUES (?, ?)", 1, JSONColumnOf(Some[jsonColumnTestDocument](doc)))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO test_table(id, doc) VALUES (?, ?)", 2, JSONColumnOf(None[jsonColumnTestDocument]()))
+	assert.NoError(t, err)
+
+	var maybeDoc JSONColumn[jsonColumnTestDocument]
+
+	row := db.QueryRow("SELECT doc FROM test_table WHERE id = 1")
+	err = row.Scan(&maybeDoc)
+	assert.NoError(t, err)
+	assert.Equal(t, Some[jsonColumnTestDocument](doc), maybeDoc.Option())
+
+	var title string
+	row = db.QueryRow("SELECT json_extract(doc, '$.title') FROM test_table WHERE id = 1")
+	err = row.Scan(&title)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", title)
+
+	row = db.QueryRow("SELECT doc FROM test_table WHERE id = 2")
+	err = row.Scan(&maybeDoc)
+	assert.NoError(t, err)
+	assert.True(t, maybeDoc.Option().IsNone())
+
+	var isNull bool
+	row = db.QueryRow("SELECT doc IS NULL FROM test_table WHERE id = 2")
+	err = row.Scan(&isNull)
+	assert.NoError(t, err)
+	assert.True(t, isNull)
+}
@@ -0,0 +1,353 @@
+package optional
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// appendJSONPrimitive appends the JSON encoding of the value that is pointed by ptr to dst without reflection.
+// This handles only the builtin (i.e. not named) boolean, numeric and string types; the second return value reports whether the value has been handled or not.
+// When it returns false, the caller must fall back to encoding/json.
+func appendJSONPrimitive(dst []byte, ptr any) ([]byte, bool) {
+	switch v := ptr.(type) {
+	case *string:
+		if !utf8.ValidString(*v) {
+			// the replacement rule for invalid UTF-8 sequence is up to encoding/json
+			return dst, false
+		}
+		return appendJSONString(dst, *v), true
+	case *bool:
+		return strconv.AppendBool(dst, *v), true
+	case *int:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int8:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int16:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int32:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int64:
+		return strconv.AppendInt(dst, *v, 10), true
+	case *uint:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint8:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint16:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint32:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint64:
+		return strconv.AppendUint(dst, *v, 10), true
+	case *uintptr:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *float32:
+		return appendJSONFloat(dst, float64(*v), 32)
+	case *float64:
+		return appendJSONFloat(dst, *v, 64)
+	}
+	return dst, false
+}
+
+// appendJSONFloat appends the float value with the same formatting rule as encoding/json.
+// NaN and infinity values are not handled here so that encoding/json can report the error for them.
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, false
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, true
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends the quoted JSON string with the same escaping rule as encoding/json (i.e. including HTML escaping).
+// The given string must be a valid UTF-8 sequence.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if isHTMLSafeJSONByte(b) {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(s[i:])
+		// U+2028 and U+2029 are valid in JSON but not in JavaScript, so encoding/json escapes them.
+		if c == '\u2028' || c == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+func isHTMLSafeJSONByte(b byte) bool {
+	return b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&'
+}
+
+// unmarshalJSONPrimitive decodes the JSON data into the value that is pointed by ptr without reflection.
+// This handles only the builtin (i.e. not named) boolean, numeric and string types, and only the well-formed and simple input.
+// When it returns false, the pointed value is untouched and the caller must fall back to encoding/json; that also takes care of reporting the error.
+func unmarshalJSONPrimitive(data []byte, ptr any) bool {
+	switch v := ptr.(type) {
+	case *string:
+		s, ok := parseJSONPlainString(data)
+		if ok {
+			*v = s
+		}
+		return ok
+	case *bool:
+		switch string(data) {
+		case "true":
+			*v = true
+		case "false":
+			*v = false
+		default:
+			return false
+		}
+		return true
+	case *int:
+		n, ok := parseJSONInt(data, strconv.IntSize)
+		if ok {
+			*v = int(n)
+		}
+		return ok
+	case *int8:
+		n, ok := parseJSONInt(data, 8)
+		if ok {
+			*v = int8(n)
+		}
+		return ok
+	case *int16:
+		n, ok := parseJSONInt(data, 16)
+		if ok {
+			*v = int16(n)
+		}
+		return ok
+	case *int32:
+		n, ok := parseJSONInt(data, 32)
+		if ok {
+			*v = int32(n)
+		}
+		return ok
+	case *int64:
+		n, ok := parseJSONInt(data, 64)
+		if ok {
+			*v = n
+		}
+		return ok
+	case *uint:
+		n, ok := parseJSONUint(data, strconv.IntSize)
+		if ok {
+			*v = uint(n)
+		}
+		return ok
+	case *uint8:
+		n, ok := parseJSONUint(data, 8)
+		if ok {
+			*v = uint8(n)
+		}
+		return ok
+	case *uint16:
+		n, ok := parseJSONUint(data, 16)
+		if ok {
+			*v = uint16(n)
+		}
+		return ok
+	case *uint32:
+		n, ok := parseJSONUint(data, 32)
+		if ok {
+			*v = uint32(n)
+		}
+		return ok
+	case *uint64:
+		n, ok := parseJSONUint(data, 64)
+		if ok {
+			*v = n
+		}
+		return ok
+	case *uintptr:
+		n, ok := parseJSONUint(data, strconv.IntSize)
+		if ok {
+			*v = uintptr(n)
+		}
+		return ok
+	case *float32:
+		n, ok := parseJSONFloat(data, 32)
+		if ok {
+			*v = float32(n)
+		}
+		return ok
+	case *float64:
+		n, ok := parseJSONFloat(data, 64)
+		if ok {
+			*v = n
+		}
+		return ok
+	}
+	return false
+}
+
+func parseJSONInt(data []byte, bitSize int) (int64, bool) {
+	if !isJSONInteger(data) {
+		return 0, false
+	}
+	if data[0] == '-' {
+		n, ok := accumulateDigits(data[1:], 1<<(bitSize-1))
+		return -int64(n), ok
+	}
+	n, ok := accumulateDigits(data, 1<<(bitSize-1)-1)
+	return int64(n), ok
+}
+
+func parseJSONUint(data []byte, bitSize int) (uint64, bool) {
+	if len(data) > 0 && data[0] == '-' || !isJSONInteger(data) {
+		return 0, false
+	}
+	return accumulateDigits(data, 1<<bitSize-1)
+}
+
+// accumulateDigits converts the decimal digits into the number. It returns false if the number exceeds the max.
+// This is an allocation-free alternative to strconv.ParseUint for the validated input.
+func accumulateDigits(digits []byte, max uint64) (uint64, bool) {
+	var n uint64
+	for _, c := range digits {
+		d := uint64(c - '0')
+		if n > (max-d)/10 {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
+
+func parseJSONFloat(data []byte, bitSize int) (float64, bool) {
+	if !isJSONNumber(data) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(string(data), bitSize)
+	return f, err == nil
+}
+
+// parseJSONPlainString returns the content of the quoted JSON string if that doesn't contain any escape sequence, control character and invalid UTF-8 sequence.
+func parseJSONPlainString(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	content := data[1 : len(data)-1]
+	for _, b := range content {
+		if b < 0x20 || b == '"' || b == '\\' {
+			return "", false
+		}
+	}
+	if !utf8.Valid(content) {
+		return "", false
+	}
+	return string(content), true
+}
+
+// isJSONInteger returns whether the data is a JSON number that consists of an optional minus sign and digits only.
+func isJSONInteger(data []byte) bool {
+	i := skipJSONIntegerPart(data)
+	return i > 0 && i == len(data)
+}
+
+// isJSONNumber returns whether the data is a JSON number according to the grammar of RFC 8259.
+func isJSONNumber(data []byte) bool {
+	i := skipJSONIntegerPart(data)
+	if i <= 0 {
+		return false
+	}
+
+	if i < len(data) && data[i] == '.' {
+		i++
+		j := skipDigits(data, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		j := skipDigits(data, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+
+	return i == len(data)
+}
+
+// skipJSONIntegerPart returns the index just after the integer part of the JSON number. It returns -1 if the data doesn't start with a valid integer part.
+func skipJSONIntegerPart(data []byte) int {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	if i >= len(data) {
+		return -1
+	}
+	if data[i] == '0' {
+		return i + 1
+	}
+	j := skipDigits(data, i)
+	if j == i {
+		return -1
+	}
+	return j
+}
+
+func skipDigits(data []byte, i int) int {
+	for i < len(data) && '0' <= data[i] && data[i] <= '9' {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,259 @@
+package optional
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertSameJSONAsStd[T any](t *testing.T, v T) {
+	t.Helper()
+
+	expected, expectedErr := json.Marshal(v)
+	actual, actualErr := Some[T](v).MarshalJSON()
+	if expectedErr != nil {
+		assert.Error(t, actualErr)
+		return
+	}
+	assert.NoError(t, actualErr)
+	assert.Equal(t, string(expected), string(actual))
+
+	var unmarshaled Option[T]
+	err := json.Unmarshal(actual, &unmarshaled)
+	assert.NoError(t, err)
+	var expectedUnmarshaled T
+	err = json.Unmarshal(expected, &expectedUnmarshaled)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUnmarshaled, unmarshaled.Unwrap())
+}
+
+func TestOption_MarshalJSON_fastPathShouldBeCompatibleWithStd(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"foo",
+		`"quoted" \backslash\ /slash/`,
+		"<html> & </html>",
+		"\b\f\n\r\t\x00\x1f\x7f",
+		"日本語",
+		"  ",
+		"invalid \xff\xfe utf-8",
+	} {
+		assertSameJSONAsStd(t, s)
+	}
+
+	assertSameJSONAsStd(t, true)
+	assertSameJSONAsStd(t, false)
+
+	assertSameJSONAsStd(t, 0)
+	assertSameJSONAsStd(t, -123)
+	assertSameJSONAsStd(t, int8(math.MinInt8))
+	assertSameJSONAsStd(t, int16(math.MaxInt16))
+	assertSameJSONAsStd(t, int32(math.MinInt32))
+	assertSameJSONAsStd(t, int64(math.MaxInt64))
+	assertSameJSONAsStd(t, uint(123))
+	assertSameJSONAsStd(t, uint8(math.MaxUint8))
+	assertSameJSONAsStd(t, uint16(math.MaxUint16))
+	assertSameJSONAsStd(t, uint32(math.MaxUint32))
+	assertSameJSONAsStd(t, uint64(math.MaxUint64))
+	assertSameJSONAsStd(t, uintptr(42))
+
+	for _, f := range []float64{0, -0.5, 1, 123.456, 1e-7, 1e20, 1e21, -1e-300, math.MaxFloat64, math.SmallestNonzeroFloat64, math.NaN(), math.Inf(1)} {
+		assertSameJSONAsStd(t, f)
+		assertSameJSONAsStd(t, float32(f))
+	}
+}
+
+type jsonNamedInt int
+
+func (i jsonNamedInt) MarshalJSON() ([]byte, error) {
+	return []byte(`"named"`), nil
+}
+
+func (i *jsonNamedInt) UnmarshalJSON(data []byte) error {
+	*i = 42
+	return nil
+}
+
+func TestOption_MarshalJSON_shouldNotUseFastPathForNamedTypes(t *testing.T) {
+	marshal, err := Some[jsonNamedInt](1).MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `"named"`, string(marshal))
+
+	var o Option[jsonNamedInt]
+	err = json.Unmarshal([]byte(`1`), &o)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonNamedInt(42), o.Unwrap())
+}
+
+func TestOption_AppendJSON(t *testing.T) {
+	buf := []byte("[")
+	buf, err := Some[int](123).AppendJSON(buf)
+	assert.NoError(t, err)
+	buf = append(buf, ',')
+	buf, err = None[int]().AppendJSON(buf)
+	assert.NoError(t, err)
+	buf = append(buf, ',')
+	buf, err = Some[string]("foo").AppendJSON(buf)
+	assert.NoError(t, err)
+	buf = append(buf, ',')
+	buf, err = Some[[]int]([]int{1, 2}).AppendJSON(buf)
+	assert.NoError(t, err)
+	buf = append(buf, ']')
+	assert.Equal(t, `[123,null,"foo",[1,2]]`, string(buf))
+
+	buf, err = Some[chan int](make(chan int)).AppendJSON(buf)
+	assert.Error(t, err)
+	assert.Equal(t, `[123,null,"foo",[1,2]]`, string(buf))
+}
+
+func TestOption_UnmarshalJSON_fastPathShouldFallBackToStd(t *testing.T) {
+	{
+		var o Option[string]
+		err := json.Unmarshal([]byte(`"esc\"apedあ"`), &o)
+		assert.NoError(t, err)
+		assert.Equal(t, `esc"apedあ`, o.Unwrap())
+	}
+
+	{
+		var o Option[float64]
+		err := json.Unmarshal([]byte(`-1.5e+3`), &o)
+		assert.NoError(t, err)
+		assert.Equal(t, -1500.0, o.Unwrap())
+	}
+
+	for _, input := range []string{`1.5`, `1e3`, `"1"`, `true`, `300`} {
+		var o Option[int8]
+		err := json.Unmarshal([]byte(input), &o)
+		assert.Error(t, err, input)
+	}
+
+	for _, input := range []string{`-1`, `-0`} {
+		var o Option[uint]
+		err := json.Unmarshal([]byte(input), &o)
+		assert.Error(t, err, input)
+	}
+
+	for _, input := range []string{`01`, `+1`, `1.`, `.1`, `1e`, `-`} {
+		var o Option[float64]
+		err := o.UnmarshalJSON([]byte(input))
+		assert.Error(t, err, input)
+	}
+
+	{
+		var o Option[bool]
+		err := o.UnmarshalJSON([]byte(`1`))
+		assert.Error(t, err)
+	}
+}
+
+type jsonBenchmarkStruct struct {
+	ID      Option[int64]  `json:"id"`
+	Name    Option[string] `json:"name"`
+	Enabled Option[bool]   `json:"enabled"`
+	Score   Option[int]    `json:"score"`
+}
+
+type jsonBenchmarkStdOption[T any] []T
+
+// MarshalJSON is the implementation that doesn't have the fast path, for comparison.
+func (o jsonBenchmarkStdOption[T]) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return jsonNull, nil
+	}
+	return json.Marshal(o[value])
+}
+
+// UnmarshalJSON is the implementation that doesn't have the fast path, for comparison.
+func (o *jsonBenchmarkStdOption[T]) UnmarshalJSON(data []byte) error {
+	if len(data) <= 0 || string(data) == "null" {
+		*o = nil
+		return nil
+	}
+	var v T
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*o = jsonBenchmarkStdOption[T]{v}
+	return nil
+}
+
+type jsonBenchmarkStdStruct struct {
+	ID      jsonBenchmarkStdOption[int64]  `json:"id"`
+	Name    jsonBenchmarkStdOption[string] `json:"name"`
+	Enabled jsonBenchmarkStdOption[bool]   `json:"enabled"`
+	Score   jsonBenchmarkStdOption[int]    `json:"score"`
+}
+
+const jsonBenchmarkPayload = `{"id":1234567890,"name":"moznion","enabled":true,"score":null}`
+
+func BenchmarkOption_MarshalJSON(b *testing.B) {
+	v := jsonBenchmarkStruct{
+		ID:      Some[int64](1234567890),
+		Name:    Some[string]("moznion"),
+		Enabled: Some[bool](true),
+		Score:   None[int](),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(v)
+	}
+}
+
+func BenchmarkOption_MarshalJSON_withoutFastPath(b *testing.B) {
+	v := jsonBenchmarkStdStruct{
+		ID:      jsonBenchmarkStdOption[int64]{1234567890},
+		Name:    jsonBenchmarkStdOption[string]{"moznion"},
+		Enabled: jsonBenchmarkStdOption[bool]{true},
+		Score:   nil,
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(v)
+	}
+}
+
+func BenchmarkOption_UnmarshalJSON(b *testing.B) {
+	data := []byte(jsonBenchmarkPayload)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v jsonBenchmarkStruct
+		_ = json.Unmarshal(data, &v)
+	}
+}
+
+func BenchmarkOption_UnmarshalJSON_withoutFastPath(b *testing.B) {
+	data := []byte(jsonBenchmarkPayload)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v jsonBenchmarkStdStruct
+		_ = json.Unmarshal(data, &v)
+	}
+}
+
+func BenchmarkOption_AppendJSON(b *testing.B) {
+	opts := []Option[int]{Some[int](1), None[int](), Some[int](1234567890)}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, o := range opts {
+			buf, _ = o.AppendJSON(buf)
+		}
+	}
+}
+
+func BenchmarkOption_AppendJSON_withoutFastPath(b *testing.B) {
+	opts := []jsonBenchmarkStdOption[int]{{1}, nil, {1234567890}}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, o := range opts {
+			marshal, _ := o.MarshalJSON()
+			buf = append(buf, marshal...)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package optional
+
+// Lift1 converts the function that takes a value into the function that takes an Option value.
+// The returned function returns None if the given Option value is None; otherwise, this returns Some with the result of the original function.
+func Lift1[T, U any](f func(v T) U) func(opt Option[T]) Option[U] {
+	return func(opt Option[T]) Option[U] {
+		return Map(opt, f)
+	}
+}
+
+// Lift2 converts the function that takes two values into the function that takes two Option values.
+// The returned function returns None if either one of the given Option values is None; otherwise, this returns Some with the result of the original function.
+func Lift2[T1, T2, U any](f func(v1 T1, v2 T2) U) func(opt1 Option[T1], opt2 Option[T2]) Option[U] {
+	return func(opt1 Option[T1], opt2 Option[T2]) Option[U] {
+		return ZipWith(opt1, opt2, f)
+	}
+}
+
+// Lift3 converts the function that takes three values into the function that takes three Option values.
+// The returned function returns None if any one of the given Option values is None; otherwise, this returns Some with the result of the original function.
+func Lift3[T1, T2, T3, U any](f func(v1 T1, v2 T2, v3 T3) U) func(opt1 Option[T1], opt2 Option[T2], opt3 Option[T3]) Option[U] {
+	return func(opt1 Option[T1], opt2 Option[T2], opt3 Option[T3]) Option[U] {
+		if opt1.IsNone() || opt2.IsNone() || opt3.IsNone() {
+			return None[U]()
+		}
+		return Some(f(opt1[value], opt2[value], opt3[value]))
+	}
+}
+
+// Lift1WithError converts the function that takes a value and returns the value with an error into the function that takes an Option value.
+// The returned function returns (None, nil) if the given Option value is None, and (None, error) if the original function returns an error.
+// Otherwise, this returns (Some[U], nil).
+func Lift1WithError[T, U any](f func(v T) (U, error)) func(opt Option[T]) (Option[U], error) {
+	return func(opt Option[T]) (Option[U], error) {
+		return MapWithError(opt, f)
+	}
+}
+
+// Lift2WithError converts the function that takes two values and returns the value with an error into the function that takes two Option values.
+// The returned function returns (None, nil) if either one of the given Option values is None, and (None, error) if the original function returns an error.
+// Otherwise, this returns (Some[U], nil).
+func Lift2WithError[T1, T2, U any](f func(v1 T1, v2 T2) (U, error)) func(opt1 Option[T1], opt2 Option[T2]) (Option[U], error) {
+	return func(opt1 Option[T1], opt2 Option[T2]) (Option[U], error) {
+		if opt1.IsNone() || opt2.IsNone() {
+			return None[U](), nil
+		}
+
+		u, err := f(opt1[value], opt2[value])
+		if err != nil {
+			return None[U](), err
+		}
+		return Some(u), nil
+	}
+}
+
+// Lift3WithError converts the function that takes three values and returns the value with an error into the function that takes three Option values.
+// The returned function returns (None, nil) if any one of the given Option values is None, and (None, error) if the original function returns an error.
+// Otherwise, this returns (Some[U], nil).
+func Lift3WithError[T1, T2, T3, U any](f func(v1 T1, v2 T2, v3 T3) (U, error)) func(opt1 Option[T1], opt2 Option[T2], opt3 Option[T3]) (Option[U], error) {
+	return func(opt1 Option[T1], opt2 Option[T2], opt3 Option[T3]) (Option[U], error) {
+		if opt1.IsNone() || opt2.IsNone() || opt3.IsNone() {
+			return None[U](), nil
+		}
+
+		u, err := f(opt1[value], opt2[value], opt3[value])
+		if err != nil {
+			return None[U](), err
+		}
+		return Some(u), nil
+	}
+}
+
+// Apply applies the function in the Option to the value in the other Option.
+// If either one of the function and the value is None, this returns None.
+func Apply[T, U any](fn Option[func(v T) U], opt Option[T]) Option[U] {
+	return ZipWith(fn, opt, func(f func(v T) U, v T) U {
+		return f(v)
+	})
+}
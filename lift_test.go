@@ -0,0 +1,120 @@
+package optional
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLift1(t *testing.T) {
+	double := Lift1(func(v int) int {
+		return v * 2
+	})
+	assert.Equal(t, Some[int](246), double(Some[int](123)))
+	assert.True(t, double(None[int]()).IsNone())
+}
+
+func TestLift2(t *testing.T) {
+	called := false
+	add := Lift2(func(v1 int, v2 int) int {
+		called = true
+		return v1 + v2
+	})
+	assert.Equal(t, Some[int](3), add(Some[int](1), Some[int](2)))
+
+	called = false
+	assert.True(t, add(None[int](), Some[int](2)).IsNone())
+	assert.True(t, add(Some[int](1), None[int]()).IsNone())
+	assert.False(t, called)
+}
+
+func TestLift3(t *testing.T) {
+	called := false
+	format := Lift3(func(v1 string, v2 int, v3 bool) string {
+		called = true
+		return v1 + strconv.Itoa(v2) + strconv.FormatBool(v3)
+	})
+	assert.Equal(t, Some[string]("foo1true"), format(Some[string]("foo"), Some[int](1), Some[bool](true)))
+
+	called = false
+	assert.True(t, format(None[string](), Some[int](1), Some[bool](true)).IsNone())
+	assert.True(t, format(Some[string]("foo"), None[int](), Some[bool](true)).IsNone())
+	assert.True(t, format(Some[string]("foo"), Some[int](1), None[bool]()).IsNone())
+	assert.False(t, called)
+}
+
+func TestLift1WithError(t *testing.T) {
+	atoi := Lift1WithError(strconv.Atoi)
+
+	v, err := atoi(Some[string]("123"))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](123), v)
+
+	v, err = atoi(None[string]())
+	assert.NoError(t, err)
+	assert.True(t, v.IsNone())
+
+	v, err = atoi(Some[string]("foo"))
+	assert.Error(t, err)
+	assert.True(t, v.IsNone())
+}
+
+func TestLift2WithError(t *testing.T) {
+	errDivideByZero := errors.New("divide by zero")
+	div := Lift2WithError(func(v1 int, v2 int) (int, error) {
+		if v2 == 0 {
+			return 0, errDivideByZero
+		}
+		return v1 / v2, nil
+	})
+
+	v, err := div(Some[int](6), Some[int](3))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](2), v)
+
+	v, err = div(Some[int](6), None[int]())
+	assert.NoError(t, err)
+	assert.True(t, v.IsNone())
+
+	v, err = div(None[int](), Some[int](0))
+	assert.NoError(t, err)
+	assert.True(t, v.IsNone())
+
+	v, err = div(Some[int](6), Some[int](0))
+	assert.ErrorIs(t, err, errDivideByZero)
+	assert.True(t, v.IsNone())
+}
+
+func TestLift3WithError(t *testing.T) {
+	errNegative := errors.New("negative")
+	sum := Lift3WithError(func(v1 int, v2 int, v3 int) (int, error) {
+		s := v1 + v2 + v3
+		if s < 0 {
+			return 0, errNegative
+		}
+		return s, nil
+	})
+
+	v, err := sum(Some[int](1), Some[int](2), Some[int](3))
+	assert.NoError(t, err)
+	assert.Equal(t, Some[int](6), v)
+
+	v, err = sum(Some[int](1), Some[int](2), None[int]())
+	assert.NoError(t, err)
+	assert.True(t, v.IsNone())
+
+	v, err = sum(Some[int](1), Some[int](2), Some[int](-10))
+	assert.ErrorIs(t, err, errNegative)
+	assert.True(t, v.IsNone())
+}
+
+func TestApply(t *testing.T) {
+	fn := Some(func(v int) string {
+		return strconv.Itoa(v * 2)
+	})
+	assert.Equal(t, Some[string]("246"), Apply(fn, Some[int](123)))
+	assert.True(t, Apply(fn, None[int]()).IsNone())
+	assert.True(t, Apply(None[func(v int) string](), Some[int](123)).IsNone())
+}
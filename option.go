@@ -140,6 +140,35 @@ func (o Option[T]) Filter(predicate func(v T) bool) Option[T] {
 	return o
 }
 
+// And returns the `otherOptionValue` if the receiver's Option value is Some. Otherwise, this returns None value.
+// This is the counterpart of Or.
+func (o Option[T]) And(otherOptionValue Option[T]) Option[T] {
+	if o.IsNone() {
+		return None[T]()
+	}
+	return otherOptionValue
+}
+
+// AndThen executes `f` with the value of Option and returns the result value of that function if the receiver's Option value is Some. Otherwise, this returns None value.
+// This is the same as FlatMap, but this is available as a method because the types of the receiver and the result are the same.
+func (o Option[T]) AndThen(f func(v T) Option[T]) Option[T] {
+	if o.IsNone() {
+		return None[T]()
+	}
+	return f(o[value])
+}
+
+// Xor returns the Option value that is Some if exactly one of the receiver and `otherOptionValue` is Some. Otherwise, this returns None value.
+func (o Option[T]) Xor(otherOptionValue Option[T]) Option[T] {
+	if o.IsSome() && otherOptionValue.IsNone() {
+		return o
+	}
+	if o.IsNone() && otherOptionValue.IsSome() {
+		return otherOptionValue
+	}
+	return None[T]()
+}
+
 // IfSome calls given function with the value of Option if the receiver value is Some.
 func (o Option[T]) IfSome(f func(v T)) {
 	if o.IsNone() {
@@ -174,16 +203,29 @@ func (o Option[T]) IfNoneWithError(f func() error) error {
 	return f()
 }
 
+// Inspect calls given function with the value of Option if the receiver value is Some, and returns the receiver as it is.
+// This is the same as IfSome, but this can be used in the method chain.
+func (o Option[T]) Inspect(f func(v T)) Option[T] {
+	if o.IsSome() {
+		f(o[value])
+	}
+	return o
+}
+
+// String returns the string representation of the contained value (i.e. `fmt.Sprint(v)`), or an empty string if the Option is None.
+// This makes `fmt.Print(opt)` and `{{.Field}}` of text/template and html/template render the contained value.
+//
+// Note that this had returned `Some[v]` and `None[]` in the earlier versions; please use IsSome() and IsNone() to tell Some from None.
 func (o Option[T]) String() string {
 	if o.IsNone() {
-		return "None[]"
+		return ""
 	}
+	return fmt.Sprint(o[value])
+}
 
-	v := o.Unwrap()
-	if stringer, ok := interface{}(v).(fmt.Stringer); ok {
-		return fmt.Sprintf("Some[%s]", stringer)
-	}
-	return fmt.Sprintf("Some[%v]", v)
+// Contains returns whether the Option value is Some and the value equals to `v`.
+func Contains[T comparable](option Option[T], v T) bool {
+	return option.IsSome() && option[value] == v
 }
 
 // Map converts given Option value to another Option value according to the mapper function.
@@ -343,11 +385,30 @@ func (o Option[T]) MarshalJSON() ([]byte, error) {
 		return jsonNull, nil
 	}
 
-	marshal, err := json.Marshal(o.Unwrap())
+	if marshal, ok := appendJSONPrimitive(nil, &o[value]); ok {
+		return marshal, nil
+	}
+	return json.Marshal(o[value])
+}
+
+// AppendJSON appends the JSON encoding of the Option value to dst and returns the extended buffer.
+// If the Option value is None, this appends `null`. Otherwise, this appends the JSON encoding of the contained value.
+// For the builtin boolean, numeric and string types, this encodes the value without reflection; other types are encoded by encoding/json.
+// If the encoding fails, this returns dst as it is with the error.
+func (o Option[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if o.IsNone() {
+		return append(dst, jsonNull...), nil
+	}
+
+	if appended, ok := appendJSONPrimitive(dst, &o[value]); ok {
+		return appended, nil
+	}
+
+	marshal, err := json.Marshal(o[value])
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
-	return marshal, nil
+	return append(dst, marshal...), nil
 }
 
 func (o *Option[T]) UnmarshalJSON(data []byte) error {
@@ -356,12 +417,20 @@ func (o *Option[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var v T
-	err := json.Unmarshal(data, &v)
-	if err != nil {
-		return err
-	}
-	*o = Some(v)
+	// decode the value into the element of the Some value directly to avoid the extra allocation
+	some := make(Option[T], 1)
+	if !unmarshalJSONPrimitive(data, &some[value]) {
+		err := json.Unmarshal(data, &some[value])
+		if err != nil {
+			// accept the legacy payload that has been encoded from sql.Null[T] and sql.NullXxx types
+			if legacy, ok := unmarshalSQLNullJSON[T](data); ok {
+				*o = legacy
+				return nil
+			}
+			return err
+		}
+	}
+	*o = some
 
 	return nil
 }
@@ -577,11 +577,15 @@ func (m *MyStringer) String() string {
 }
 
 func TestOption_String(t *testing.T) {
-	assert.Equal(t, "Some[123]", Some[int](123).String())
-	assert.Equal(t, "None[]", None[int]().String())
+	assert.Equal(t, "123", Some[int](123).String())
+	assert.Equal(t, "", None[int]().String())
 
-	assert.Equal(t, "Some[mystr]", Some[*MyStringer](&MyStringer{}).String())
-	assert.Equal(t, "None[]", None[*MyStringer]().String())
+	assert.Equal(t, "mystr", Some[*MyStringer](&MyStringer{}).String())
+	assert.Equal(t, "", None[*MyStringer]().String())
+
+	assert.Equal(t, "123", fmt.Sprint(Some[int](123)))
+	assert.Equal(t, "[1 2]", fmt.Sprintf("%v", Some[[]int]([]int{1, 2})))
+	assert.Equal(t, "", fmt.Sprintf("%s", None[string]()))
 }
 
 func TestOption_Or(t *testing.T) {
@@ -597,3 +601,65 @@ func TestOption_OrElse(t *testing.T) {
 	assert.EqualValues(t, Some[string]("actual").OrElse(fallbackFunc).Unwrap(), "actual")
 	assert.EqualValues(t, None[string]().OrElse(fallbackFunc).Unwrap(), "fallback")
 }
+
+func TestOption_And(t *testing.T) {
+	other := Some[string]("other")
+
+	assert.Equal(t, other, Some[string]("actual").And(other))
+	assert.True(t, Some[string]("actual").And(None[string]()).IsNone())
+	assert.True(t, None[string]().And(other).IsNone())
+	assert.True(t, None[string]().And(None[string]()).IsNone())
+}
+
+func TestOption_AndThen(t *testing.T) {
+	half := func(v int) Option[int] {
+		if v%2 != 0 {
+			return None[int]()
+		}
+		return Some[int](v / 2)
+	}
+
+	assert.Equal(t, Some[int](3), Some[int](12).AndThen(half).AndThen(half))
+	assert.True(t, Some[int](6).AndThen(half).AndThen(half).IsNone())
+
+	called := false
+	assert.True(t, None[int]().AndThen(func(v int) Option[int] {
+		called = true
+		return Some[int](v)
+	}).IsNone())
+	assert.False(t, called)
+}
+
+func TestOption_Xor(t *testing.T) {
+	some1 := Some[string]("foo")
+	some2 := Some[string]("bar")
+	none := None[string]()
+
+	assert.Equal(t, some1, some1.Xor(none))
+	assert.Equal(t, some2, none.Xor(some2))
+	assert.True(t, some1.Xor(some2).IsNone())
+	assert.True(t, none.Xor(none).IsNone())
+}
+
+func TestOption_Inspect(t *testing.T) {
+	var inspected []int
+	inspect := func(v int) {
+		inspected = append(inspected, v)
+	}
+
+	opt := Some[int](123).Inspect(inspect).Filter(func(v int) bool {
+		return v > 100
+	}).Inspect(inspect)
+	assert.Equal(t, Some[int](123), opt)
+	assert.Equal(t, []int{123, 123}, inspected)
+
+	inspected = nil
+	assert.True(t, None[int]().Inspect(inspect).IsNone())
+	assert.Nil(t, inspected)
+}
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains(Some[int](123), 123))
+	assert.False(t, Contains(Some[int](123), 456))
+	assert.False(t, Contains(None[int](), 0))
+}
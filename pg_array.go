@@ -0,0 +1,396 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PGArray is a slice of Option values for the PostgreSQL array column that can contain NULL elements (e.g. `int[]` value like `{1,NULL,3}`).
+// This implements database/sql.Scanner and database/sql/driver.Valuer with the PostgreSQL array literal syntax (i.e. the text representation of the array).
+//
+// The NULL element is dealt with as None. The multi-dimensional array can be represented by the nested PGArray, e.g. PGArray[PGArray[int]] for `{{1,2},{3,NULL}}`.
+// If the array column itself is nullable, please use Option[PGArray[T]]; that scans SQL NULL as None.
+type PGArray[T any] []Option[T]
+
+// pgArrayLiteral is an interface to tell the value is the PostgreSQL array literal, so that should be embedded into the outer array without quoting.
+type pgArrayLiteral interface {
+	isPGArrayLiteral()
+}
+
+func (a PGArray[T]) isPGArrayLiteral() {}
+
+// Scan parses the PostgreSQL array literal from a database driver.
+// If the src is nil (i.e. SQL NULL), the PGArray becomes nil. Each element is scanned by Option#Scan().
+// For the []byte and time.Time elements, the text representation of PostgreSQL (i.e. the bytea hex format like `\x0102` and the timestamp like `2006-01-02 15:04:05+09`)
+// is decoded before that, because PostgreSQL gives the elements as the text in the array literal.
+// This method is required from database/sql.Scanner interface.
+func (a *PGArray[T]) Scan(src any) error {
+	var text string
+	switch s := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		text = s
+	case []byte:
+		text = string(s)
+	default:
+		return fmt.Errorf("unsupported type to scan PostgreSQL array: %T", src)
+	}
+
+	elements, err := parsePGArray(text)
+	if err != nil {
+		return err
+	}
+
+	decode := pgArrayElementDecoderOf(reflect.TypeOf((*T)(nil)).Elem())
+	array := make(PGArray[T], len(elements))
+	for i, elem := range elements {
+		if elem.null {
+			continue
+		}
+		v, err := decode(elem.text)
+		if err != nil {
+			return fmt.Errorf("failed to scan the element at index %d of PostgreSQL array: %w", i, err)
+		}
+		err = array[i].Scan(v)
+		if err != nil {
+			return fmt.Errorf("failed to scan the element at index %d of PostgreSQL array: %w", i, err)
+		}
+	}
+	*a = array
+	return nil
+}
+
+var (
+	pgArrayTimeType = reflect.TypeOf(time.Time{})
+
+	// pgTimestampLayouts are the layouts of the text representation of PostgreSQL's timestamp, timestamptz and date,
+	// and RFC 3339 that Value writes. The fractional seconds are accepted even though the layouts don't have that.
+	pgTimestampLayouts = []string{
+		"2006-01-02 15:04:05Z07:00:00",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05Z07",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		time.RFC3339Nano,
+	}
+)
+
+// pgArrayElementDecoderOf returns the function to decode the text of the array element into the value that is given to Option#Scan().
+func pgArrayElementDecoderOf(typ reflect.Type) func(text string) (any, error) {
+	switch {
+	case typ == pgArrayTimeType:
+		return decodePGTimestamp
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return decodePGBytea
+	}
+	return func(text string) (any, error) {
+		return text, nil
+	}
+}
+
+// decodePGBytea decodes the bytea hex format (e.g. `\x0102`). The text that isn't the hex format is returned as it is.
+func decodePGBytea(text string) (any, error) {
+	hexText, ok := strings.CutPrefix(text, `\x`)
+	if !ok {
+		return []byte(text), nil
+	}
+	return hex.DecodeString(hexText)
+}
+
+func decodePGTimestamp(text string) (any, error) {
+	for _, layout := range pgTimestampLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid PostgreSQL timestamp: %q", text)
+}
+
+// Value formats the PGArray as the PostgreSQL array literal. If the PGArray is nil, this returns nil (i.e. SQL NULL).
+// Each element is converted by Option#Value(), and None element is written as NULL.
+// This method is required from database/sql/driver.Valuer interface.
+func (a PGArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, elem := range a {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		if elem.IsNone() {
+			sb.WriteString("NULL")
+			continue
+		}
+
+		v, err := elem.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the element at index %d of PostgreSQL array: %w", i, err)
+		}
+		if _, ok := any(elem.Unwrap()).(pgArrayLiteral); ok && v != nil {
+			// the nested array (i.e. multi-dimensional array) is embedded as it is
+			sb.WriteString(v.(string))
+			continue
+		}
+		text, ok := formatPGArrayElement(v)
+		if !ok {
+			sb.WriteString("NULL")
+			continue
+		}
+		writePGArrayElement(&sb, text)
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+// formatPGArrayElement formats the driver value as the text representation of the array element. If the value is nil, this returns false.
+func formatPGArrayElement(v driver.Value) (string, bool) {
+	switch value := v.(type) {
+	case nil:
+		return "", false
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	case float64:
+		switch {
+		case math.IsInf(value, 1):
+			return "Infinity", true
+		case math.IsInf(value, -1):
+			return "-Infinity", true
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64), true
+	case bool:
+		return strconv.FormatBool(value), true
+	case []byte:
+		// bytea hex format
+		return `\x` + hex.EncodeToString(value), true
+	case string:
+		return value, true
+	case time.Time:
+		return value.Format(time.RFC3339Nano), true
+	}
+	return fmt.Sprint(v), true
+}
+
+// writePGArrayElement writes the element with quoting and escaping if necessary.
+func writePGArrayElement(sb *strings.Builder, text string) {
+	if !needsPGArrayQuote(text) {
+		sb.WriteString(text)
+		return
+	}
+
+	sb.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c == '"' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(text[i])
+	}
+	sb.WriteByte('"')
+}
+
+func needsPGArrayQuote(text string) bool {
+	if text == "" || strings.EqualFold(text, "NULL") {
+		return true
+	}
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '{', '}', ',', '"', '\\', ' ', '\t', '\n', '\r', '\v', '\f':
+			return true
+		}
+	}
+	return false
+}
+
+// pgArrayElement is an element of the parsed PostgreSQL array literal.
+// If the element is a nested array, the text is the raw literal of that array.
+type pgArrayElement struct {
+	text string
+	null bool
+}
+
+// parsePGArray parses the one dimension of the PostgreSQL array literal, e.g. `{1,NULL,"a b"}` and `[1:2]={{1,2},{3,4}}`.
+// The nested arrays are not parsed, and those are returned as the raw literal.
+func parsePGArray(s string) ([]pgArrayElement, error) {
+	p := &pgArrayParser{s: s}
+	p.skipSpaces()
+
+	// skip the dimension decoration, e.g. `[1:3]=`
+	if p.peek() == '[' {
+		eq := strings.IndexByte(p.s[p.pos:], '=')
+		if eq < 0 {
+			return nil, p.errorf("missing '=' after dimension decoration")
+		}
+		p.pos += eq + 1
+		p.skipSpaces()
+	}
+
+	if p.peek() != '{' {
+		return nil, p.errorf("array literal must start with '{'")
+	}
+	p.pos++
+	p.skipSpaces()
+
+	elements := make([]pgArrayElement, 0)
+	if p.peek() == '}' {
+		p.pos++
+	} else {
+		for {
+			p.skipSpaces()
+			elem, err := p.parseElement()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, elem)
+
+			p.skipSpaces()
+			c := p.peek()
+			p.pos++
+			if c == ',' {
+				continue
+			}
+			if c == '}' {
+				break
+			}
+			return nil, p.errorf("unexpected character, expected ',' or '}'")
+		}
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected trailing characters")
+	}
+	return elements, nil
+}
+
+type pgArrayParser struct {
+	s   string
+	pos int
+}
+
+func (p *pgArrayParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid PostgreSQL array literal %q at position %d: %s", p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+// peek returns the current character, or 0 if the parser reaches the end.
+func (p *pgArrayParser) peek() byte {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *pgArrayParser) skipSpaces() {
+	for p.pos < len(p.s) && isPGArraySpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+func isPGArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func (p *pgArrayParser) parseElement() (pgArrayElement, error) {
+	switch p.peek() {
+	case '{':
+		return p.parseNestedArray()
+	case '"':
+		return p.parseQuoted()
+	}
+	return p.parseUnquoted()
+}
+
+// parseNestedArray returns the raw literal of the nested array.
+func (p *pgArrayParser) parseNestedArray() (pgArrayElement, error) {
+	start := p.pos
+	depth := 0
+	inQuote := false
+	for ; p.pos < len(p.s); p.pos++ {
+		switch c := p.s[p.pos]; {
+		case c == '\\':
+			p.pos++
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return pgArrayElement{text: p.s[start:p.pos]}, nil
+			}
+		}
+	}
+	return pgArrayElement{}, p.errorf("unterminated nested array")
+}
+
+func (p *pgArrayParser) parseQuoted() (pgArrayElement, error) {
+	p.pos++ // opening quote
+	var sb strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case '\\':
+			if p.pos >= len(p.s) {
+				return pgArrayElement{}, p.errorf("unterminated escape sequence")
+			}
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+		case '"':
+			return pgArrayElement{text: sb.String()}, nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return pgArrayElement{}, p.errorf("unterminated quoted element")
+}
+
+func (p *pgArrayParser) parseUnquoted() (pgArrayElement, error) {
+	var sb strings.Builder
+	// the trailing spaces are not a part of the unquoted element, but the escaped ones are
+	trimmedLen := 0
+	escaped := false
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == ',' || c == '}' {
+			break
+		}
+		if c == '{' || c == '"' {
+			return pgArrayElement{}, p.errorf("unexpected %q in unquoted element", c)
+		}
+		p.pos++
+		if c == '\\' {
+			if p.pos >= len(p.s) {
+				return pgArrayElement{}, p.errorf("unterminated escape sequence")
+			}
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+			trimmedLen = sb.Len()
+			escaped = true
+			continue
+		}
+		sb.WriteByte(c)
+		if !isPGArraySpace(c) {
+			trimmedLen = sb.Len()
+		}
+	}
+
+	text := sb.String()[:trimmedLen]
+	if text == "" {
+		return pgArrayElement{}, p.errorf("empty unquoted element")
+	}
+	// the escaped NULL (e.g. `N\ULL`) is not a NULL element, the same as the quoted one
+	return pgArrayElement{text: text, null: !escaped && strings.EqualFold(text, "NULL")}, nil
+}
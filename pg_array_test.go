@@ -0,0 +1,306 @@
+package optional
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPGArray_Scan(t *testing.T) {
+	var ints PGArray[int]
+	err := ints.Scan("{1,NULL,3}")
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[int]{Some(1), None[int](), Some(3)}, ints)
+
+	err = ints.Scan([]byte(" { 4 , null } "))
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[int]{Some(4), None[int]()}, ints)
+
+	err = ints.Scan("{}")
+	assert.NoError(t, err)
+	assert.NotNil(t, ints)
+	assert.Len(t, ints, 0)
+
+	err = ints.Scan(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ints)
+
+	var bools PGArray[bool]
+	err = bools.Scan("{t,f,NULL}")
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[bool]{Some(true), Some(false), None[bool]()}, bools)
+
+	var floats PGArray[float64]
+	err = floats.Scan("{1.5,-2e3}")
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[float64]{Some(1.5), Some(-2e3)}, floats)
+}
+
+func TestPGArray_Scan_QuotedAndEscaped(t *testing.T) {
+	var strs PGArray[string]
+	err := strs.Scan(`{"a b","",NULL,"NULL",N\ULL,"quote\"d","back\\slash","{brace}",a\,b,  trimmed  }`)
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[string]{
+		Some("a b"),
+		Some(""),
+		None[string](),
+		Some("NULL"),
+		Some("NULL"),
+		Some(`quote"d`),
+		Some(`back\slash`),
+		Some("{brace}"),
+		Some("a,b"),
+		Some("trimmed"),
+	}, strs)
+}
+
+func TestPGArray_Scan_MultiDimensional(t *testing.T) {
+	var matrix PGArray[PGArray[int]]
+	err := matrix.Scan("{{1,2},{3,NULL}}")
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[PGArray[int]]{
+		Some(PGArray[int]{Some(1), Some(2)}),
+		Some(PGArray[int]{Some(3), None[int]()}),
+	}, matrix)
+
+	err = matrix.Scan("[1:2][0:1]={{1,2},{3,4}}")
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[PGArray[int]]{
+		Some(PGArray[int]{Some(1), Some(2)}),
+		Some(PGArray[int]{Some(3), Some(4)}),
+	}, matrix)
+
+	var strMatrix PGArray[PGArray[string]]
+	err = strMatrix.Scan(`{{"}",a},{"\"{",NULL}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[PGArray[string]]{
+		Some(PGArray[string]{Some("}"), Some("a")}),
+		Some(PGArray[string]{Some(`"{`), None[string]()}),
+	}, strMatrix)
+}
+
+func TestPGArray_Scan_Invalid(t *testing.T) {
+	for _, literal := range []string{
+		"",
+		"1,2",
+		"{1,2",
+		"{1,,2}",
+		"{1,2}x",
+		`{"unterminated}`,
+		`{a"b}`,
+		"{{1,2}",
+		"[1:2]{1,2}",
+		`{a\`,
+	} {
+		var a PGArray[string]
+		assert.Error(t, a.Scan(literal), literal)
+	}
+
+	var ints PGArray[int]
+	err := ints.Scan("{1,x}")
+	assert.ErrorContains(t, err, "index 1")
+
+	err = ints.Scan(int64(1))
+	assert.Error(t, err)
+}
+
+func TestPGArray_Value(t *testing.T) {
+	v, err := PGArray[int]{Some(1), None[int](), Some(3)}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{1,NULL,3}", v)
+
+	v, err = PGArray[int]{}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", v)
+
+	v, err = PGArray[int](nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = PGArray[string]{Some("plain"), Some("a b"), Some(""), Some("null"), Some(`q"b\`), Some("{x,y}"), None[string]()}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{plain,"a b","","null","q\"b\\","{x,y}",NULL}`, v)
+
+	v, err = PGArray[float64]{Some(1.5), Some(math.Inf(1)), Some(math.Inf(-1)), Some(math.NaN())}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{1.5,Infinity,-Infinity,NaN}", v)
+
+	v, err = PGArray[bool]{Some(true), Some(false)}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{true,false}", v)
+
+	v, err = PGArray[[]byte]{Some([]byte{0x01, 0xab})}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"\\x01ab"}`, v)
+
+	v, err = PGArray[PGArray[string]]{
+		Some(PGArray[string]{Some("a b"), None[string]()}),
+		Some(PGArray[string]{Some("c"), Some("d")}),
+	}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{{"a b",NULL},{c,d}}`, v)
+}
+
+func TestPGArray_RoundTrip(t *testing.T) {
+	original := PGArray[string]{Some(`a "quoted", {braced} \ value`), None[string](), Some("NULL"), Some(" spaces "), Some("")}
+	v, err := original.Value()
+	assert.NoError(t, err)
+
+	var scanned PGArray[string]
+	err = scanned.Scan(v)
+	assert.NoError(t, err)
+	assert.Equal(t, original, scanned)
+}
+
+// echoDriver is a fake database/sql driver that returns the bound argument as the single column of the single row.
+// This is used to test the Scanner and Valuer implementations through database/sql without the real database.
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) {
+	return echoConn{}, nil
+}
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) {
+	return echoStmt{}, nil
+}
+
+func (echoConn) Close() error {
+	return nil
+}
+
+func (echoConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error {
+	return nil
+}
+
+func (echoStmt) NumInput() int {
+	return 1
+}
+
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{value: args[0]}, nil
+}
+
+type echoRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *echoRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *echoRows) Close() error {
+	return nil
+}
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	if s, ok := r.value.(string); ok {
+		// the PostgreSQL drivers give the array literal as []byte
+		dest[0] = []byte(s)
+		return nil
+	}
+	dest[0] = r.value
+	return nil
+}
+
+func init() {
+	sql.Register("optional-echo", echoDriver{})
+}
+
+func TestPGArray_ThroughDatabaseSQL(t *testing.T) {
+	db, err := sql.Open("optional-echo", "")
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+	ctx := context.Background()
+
+	var ints PGArray[int64]
+	err = db.QueryRowContext(ctx, "SELECT ?", PGArray[int64]{Some[int64](1), None[int64](), Some[int64](3)}).Scan(&ints)
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[int64]{Some[int64](1), None[int64](), Some[int64](3)}, ints)
+
+	var matrix PGArray[PGArray[string]]
+	err = db.QueryRowContext(ctx, "SELECT ?", PGArray[PGArray[string]]{
+		Some(PGArray[string]{Some("a,b"), None[string]()}),
+	}).Scan(&matrix)
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[PGArray[string]]{Some(PGArray[string]{Some("a,b"), None[string]()})}, matrix)
+
+	// the nullable array column
+	var nullable Option[PGArray[string]]
+	err = db.QueryRowContext(ctx, "SELECT ?", Some(PGArray[string]{Some("x"), None[string]()})).Scan(&nullable)
+	assert.NoError(t, err)
+	assert.Equal(t, Some(PGArray[string]{Some("x"), None[string]()}), nullable)
+
+	err = db.QueryRowContext(ctx, "SELECT ?", None[PGArray[string]]()).Scan(&nullable)
+	assert.NoError(t, err)
+	assert.True(t, nullable.IsNone())
+}
+
+func TestPGArray_Bytes(t *testing.T) {
+	original := PGArray[[]byte]{Some([]byte{0x01, 0x02}), None[[]byte](), Some([]byte{})}
+	v, err := original.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"\\x0102",NULL,"\\x"}`, v)
+
+	var scanned PGArray[[]byte]
+	err = scanned.Scan(v)
+	assert.NoError(t, err)
+	assert.Equal(t, original, scanned)
+
+	// the text of bytea[] column from PostgreSQL
+	err = scanned.Scan([]byte(`{"\\xdeadbeef",NULL}`))
+	assert.NoError(t, err)
+	assert.Equal(t, PGArray[[]byte]{Some([]byte{0xde, 0xad, 0xbe, 0xef}), None[[]byte]()}, scanned)
+
+	err = scanned.Scan(`{"\\xzz"}`)
+	assert.ErrorContains(t, err, "index 0")
+}
+
+func TestPGArray_Time(t *testing.T) {
+	original := PGArray[time.Time]{Some(time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)), None[time.Time]()}
+	v, err := original.Value()
+	assert.NoError(t, err)
+
+	var scanned PGArray[time.Time]
+	err = scanned.Scan(v)
+	assert.NoError(t, err)
+	assert.Equal(t, original, scanned)
+
+	// the text of timestamptz[], timestamp[] and date[] columns from PostgreSQL
+	err = scanned.Scan([]byte(`{"2024-01-02 03:04:05+00","2024-01-02 03:04:05.123456+05:30","1900-01-01 00:00:00+09:18:59","2024-01-02 03:04:05",2024-01-02,NULL}`))
+	assert.NoError(t, err)
+	assert.Len(t, scanned, 6)
+	assert.True(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).Equal(scanned[0].Unwrap()))
+	assert.True(t, time.Date(2024, 1, 1, 21, 34, 5, 123456000, time.UTC).Equal(scanned[1].Unwrap()))
+	assert.True(t, time.Date(1899, 12, 31, 14, 41, 1, 0, time.UTC).Equal(scanned[2].Unwrap()))
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), scanned[3].Unwrap())
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), scanned[4].Unwrap())
+	assert.True(t, scanned[5].IsNone())
+
+	err = scanned.Scan(`{"not a timestamp"}`)
+	assert.ErrorContains(t, err, "invalid PostgreSQL timestamp")
+}
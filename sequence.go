@@ -0,0 +1,120 @@
+package optional
+
+// Sequence converts the slice of Option values into the Option of the slice that has each Option's value.
+// If any one of the Option values is None, this returns None. If the given slice is empty, this returns Some with an empty slice.
+func Sequence[T any](opts []Option[T]) Option[[]T] {
+	seq, _ := SequenceWithNoneIndex(opts)
+	return seq
+}
+
+// SequenceWithNoneIndex is the same as Sequence, but this also returns the index of the first None value in the given slice.
+// If all the Option values are Some, the second return value is None.
+func SequenceWithNoneIndex[T any](opts []Option[T]) (Option[[]T], Option[int]) {
+	vs := make([]T, 0, len(opts))
+	for i, opt := range opts {
+		if opt.IsNone() {
+			return None[[]T](), Some(i)
+		}
+		vs = append(vs, opt[value])
+	}
+	return Some(vs), None[int]()
+}
+
+// Traverse converts each value of the slice according to the mapper function that returns an Option value, and returns the Option of the slice that has the converted values.
+// If the mapper returns None for any one of the values, this returns None without calling the mapper for the rest of the values.
+func Traverse[T, U any](vs []T, mapper func(v T) Option[U]) Option[[]U] {
+	traversed, _ := TraverseWithNoneIndex(vs, mapper)
+	return traversed
+}
+
+// TraverseWithNoneIndex is the same as Traverse, but this also returns the index of the value that the mapper returned None for.
+// If the mapper returns Some for all the values, the second return value is None.
+func TraverseWithNoneIndex[T, U any](vs []T, mapper func(v T) Option[U]) (Option[[]U], Option[int]) {
+	us := make([]U, 0, len(vs))
+	for i, v := range vs {
+		u := mapper(v)
+		if u.IsNone() {
+			return None[[]U](), Some(i)
+		}
+		us = append(us, u[value])
+	}
+	return Some(us), None[int]()
+}
+
+// TraverseWithError is the same as Traverse, but the mapper function has the ability to return the Option value with an error.
+// If the mapper returns an error, this returns (None, error). Else if the mapper returns None for any one of the values, this returns (None, nil).
+// Unless of them, this returns (Some[[]U], nil).
+func TraverseWithError[T, U any](vs []T, mapper func(v T) (Option[U], error)) (Option[[]U], error) {
+	us := make([]U, 0, len(vs))
+	for _, v := range vs {
+		u, err := mapper(v)
+		if err != nil {
+			return None[[]U](), err
+		}
+		if u.IsNone() {
+			return None[[]U](), nil
+		}
+		us = append(us, u[value])
+	}
+	return Some(us), nil
+}
+
+// SequenceMap converts the map of Option values into the Option of the map that has each Option's value.
+// If any one of the Option values is None, this returns None. If the given map is empty, this returns Some with an empty map.
+func SequenceMap[K comparable, V any](opts map[K]Option[V]) Option[map[K]V] {
+	seq, _ := SequenceMapWithNoneKey(opts)
+	return seq
+}
+
+// SequenceMapWithNoneKey is the same as SequenceMap, but this also returns the key of a None value in the given map.
+// If the map has multiple None values, which key is returned is unspecified because of the map's iteration order.
+// If all the Option values are Some, the second return value is None.
+func SequenceMapWithNoneKey[K comparable, V any](opts map[K]Option[V]) (Option[map[K]V], Option[K]) {
+	vs := make(map[K]V, len(opts))
+	for k, opt := range opts {
+		if opt.IsNone() {
+			return None[map[K]V](), Some(k)
+		}
+		vs[k] = opt[value]
+	}
+	return Some(vs), None[K]()
+}
+
+// TraverseMap converts each value of the map according to the mapper function that returns an Option value, and returns the Option of the map that has the converted values with the same keys.
+// If the mapper returns None for any one of the values, this returns None without calling the mapper for the rest of the values.
+func TraverseMap[K comparable, V, U any](vs map[K]V, mapper func(v V) Option[U]) Option[map[K]U] {
+	traversed, _ := TraverseMapWithNoneKey(vs, mapper)
+	return traversed
+}
+
+// TraverseMapWithNoneKey is the same as TraverseMap, but this also returns the key of the value that the mapper returned None for.
+// If the mapper returns Some for all the values, the second return value is None.
+func TraverseMapWithNoneKey[K comparable, V, U any](vs map[K]V, mapper func(v V) Option[U]) (Option[map[K]U], Option[K]) {
+	us := make(map[K]U, len(vs))
+	for k, v := range vs {
+		u := mapper(v)
+		if u.IsNone() {
+			return None[map[K]U](), Some(k)
+		}
+		us[k] = u[value]
+	}
+	return Some(us), None[K]()
+}
+
+// TraverseMapWithError is the same as TraverseMap, but the mapper function has the ability to return the Option value with an error.
+// If the mapper returns an error, this returns (None, error). Else if the mapper returns None for any one of the values, this returns (None, nil).
+// Unless of them, this returns (Some[map[K]U], nil).
+func TraverseMapWithError[K comparable, V, U any](vs map[K]V, mapper func(v V) (Option[U], error)) (Option[map[K]U], error) {
+	us := make(map[K]U, len(vs))
+	for k, v := range vs {
+		u, err := mapper(v)
+		if err != nil {
+			return None[map[K]U](), err
+		}
+		if u.IsNone() {
+			return None[map[K]U](), nil
+		}
+		us[k] = u[value]
+	}
+	return Some(us), nil
+}
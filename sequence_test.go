@@ -0,0 +1,133 @@
+package optional
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseInt(v string) Option[int] {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return None[int]()
+	}
+	return Some(i)
+}
+
+func TestSequence(t *testing.T) {
+	assert.Equal(t, Some([]int{1, 2, 3}), Sequence([]Option[int]{Some[int](1), Some[int](2), Some[int](3)}))
+	assert.True(t, Sequence([]Option[int]{Some[int](1), None[int](), Some[int](3)}).IsNone())
+	assert.Equal(t, Some([]int{}), Sequence([]Option[int]{}))
+	assert.Equal(t, Some([]int{}), Sequence[int](nil))
+}
+
+func TestSequenceWithNoneIndex(t *testing.T) {
+	seq, noneIndex := SequenceWithNoneIndex([]Option[int]{Some[int](1), Some[int](2)})
+	assert.Equal(t, Some([]int{1, 2}), seq)
+	assert.True(t, noneIndex.IsNone())
+
+	seq, noneIndex = SequenceWithNoneIndex([]Option[int]{Some[int](1), None[int](), None[int]()})
+	assert.True(t, seq.IsNone())
+	assert.Equal(t, Some[int](1), noneIndex)
+}
+
+func TestTraverse(t *testing.T) {
+	assert.Equal(t, Some([]int{1, 2, 3}), Traverse([]string{"1", "2", "3"}, parseInt))
+	assert.Equal(t, Some([]int{}), Traverse([]string{}, parseInt))
+
+	called := 0
+	traversed := Traverse([]string{"1", "foo", "3"}, func(v string) Option[int] {
+		called++
+		return parseInt(v)
+	})
+	assert.True(t, traversed.IsNone())
+	assert.Equal(t, 2, called)
+}
+
+func TestTraverseWithNoneIndex(t *testing.T) {
+	traversed, noneIndex := TraverseWithNoneIndex([]string{"1", "2"}, parseInt)
+	assert.Equal(t, Some([]int{1, 2}), traversed)
+	assert.True(t, noneIndex.IsNone())
+
+	traversed, noneIndex = TraverseWithNoneIndex([]string{"1", "2", "foo"}, parseInt)
+	assert.True(t, traversed.IsNone())
+	assert.Equal(t, Some[int](2), noneIndex)
+}
+
+func TestTraverseWithError(t *testing.T) {
+	errNegative := errors.New("negative")
+	mapper := func(v string) (Option[int], error) {
+		i := parseInt(v)
+		if i.IsSome() && i.Unwrap() < 0 {
+			return None[int](), errNegative
+		}
+		return i, nil
+	}
+
+	traversed, err := TraverseWithError([]string{"1", "2"}, mapper)
+	assert.NoError(t, err)
+	assert.Equal(t, Some([]int{1, 2}), traversed)
+
+	traversed, err = TraverseWithError([]string{"1", "foo", "-1"}, mapper)
+	assert.NoError(t, err)
+	assert.True(t, traversed.IsNone())
+
+	traversed, err = TraverseWithError([]string{"1", "-1", "foo"}, mapper)
+	assert.ErrorIs(t, err, errNegative)
+	assert.True(t, traversed.IsNone())
+}
+
+func TestSequenceMap(t *testing.T) {
+	assert.Equal(t, Some(map[string]int{"a": 1, "b": 2}), SequenceMap(map[string]Option[int]{"a": Some[int](1), "b": Some[int](2)}))
+	assert.True(t, SequenceMap(map[string]Option[int]{"a": Some[int](1), "b": None[int]()}).IsNone())
+	assert.Equal(t, Some(map[string]int{}), SequenceMap(map[string]Option[int]{}))
+}
+
+func TestSequenceMapWithNoneKey(t *testing.T) {
+	seq, noneKey := SequenceMapWithNoneKey(map[string]Option[int]{"a": Some[int](1), "b": Some[int](2)})
+	assert.Equal(t, Some(map[string]int{"a": 1, "b": 2}), seq)
+	assert.True(t, noneKey.IsNone())
+
+	seq, noneKey = SequenceMapWithNoneKey(map[string]Option[int]{"a": Some[int](1), "b": None[int]()})
+	assert.True(t, seq.IsNone())
+	assert.Equal(t, Some[string]("b"), noneKey)
+}
+
+func TestTraverseMap(t *testing.T) {
+	assert.Equal(t, Some(map[string]int{"a": 1, "b": 2}), TraverseMap(map[string]string{"a": "1", "b": "2"}, parseInt))
+	assert.True(t, TraverseMap(map[string]string{"a": "1", "b": "foo"}, parseInt).IsNone())
+}
+
+func TestTraverseMapWithNoneKey(t *testing.T) {
+	traversed, noneKey := TraverseMapWithNoneKey(map[string]string{"a": "1", "b": "2"}, parseInt)
+	assert.Equal(t, Some(map[string]int{"a": 1, "b": 2}), traversed)
+	assert.True(t, noneKey.IsNone())
+
+	traversed, noneKey = TraverseMapWithNoneKey(map[string]string{"a": "1", "b": "foo"}, parseInt)
+	assert.True(t, traversed.IsNone())
+	assert.Equal(t, Some[string]("b"), noneKey)
+}
+
+func TestTraverseMapWithError(t *testing.T) {
+	errEmpty := errors.New("empty")
+	mapper := func(v string) (Option[int], error) {
+		if v == "" {
+			return None[int](), errEmpty
+		}
+		return parseInt(v), nil
+	}
+
+	traversed, err := TraverseMapWithError(map[string]string{"a": "1", "b": "2"}, mapper)
+	assert.NoError(t, err)
+	assert.Equal(t, Some(map[string]int{"a": 1, "b": 2}), traversed)
+
+	traversed, err = TraverseMapWithError(map[string]string{"a": "1", "b": "foo"}, mapper)
+	assert.NoError(t, err)
+	assert.True(t, traversed.IsNone())
+
+	traversed, err = TraverseMapWithError(map[string]string{"a": "1", "b": ""}, mapper)
+	assert.ErrorIs(t, err, errEmpty)
+	assert.True(t, traversed.IsNone())
+}
@@ -3,11 +3,33 @@ package optional
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
+	"encoding/json"
 )
 
 // Scan assigns a value from a database driver.
 // This method is required from database/sql.Scanner interface.
+//
+// If the src is nil (i.e. SQL NULL), the Option becomes None. Otherwise, this scans the value in the following order:
+//  1. if *T implements database/sql.Scanner, this delegates to that
+//  2. if the src is string or []byte and *T implements encoding.TextUnmarshaler, this delegates to that
+//  3. if the src is []byte and *T implements encoding.BinaryUnmarshaler, this delegates to that
+//  4. otherwise, this follows the standard rules of database/sql for assigning scanned values
 func (o *Option[T]) Scan(src any) error {
+	if src == nil {
+		*o = None[T]()
+		return nil
+	}
+
+	var scanned T
+	if ok, err := scanByInterface(&scanned, src); ok {
+		if err != nil {
+			return err
+		}
+		*o = Some[T](scanned)
+		return nil
+	}
+
 	// The detour through sql.Null[T] allows us to access the standard rules for
 	// assigning scanned values into builtin types and std types like *sql.Rows,
 	// which are not exported from std directly.
@@ -16,19 +38,99 @@ func (o *Option[T]) Scan(src any) error {
 	if err != nil {
 		return err
 	}
-	if v.Valid {
-		*o = Some[T](v.V)
-	} else {
-		*o = None[T]()
-	}
+	*o = FromSQLNull(v)
 	return nil
 }
 
+// scanByInterface scans the src into dest through the interface that is implemented by dest.
+// The first return value reports whether dest implements the suitable interface for the src or not.
+func scanByInterface(dest any, src any) (bool, error) {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return true, scanner.Scan(src)
+	}
+
+	switch s := src.(type) {
+	case string:
+		if u, ok := dest.(encoding.TextUnmarshaler); ok {
+			return true, u.UnmarshalText([]byte(s))
+		}
+	case []byte:
+		if u, ok := dest.(encoding.TextUnmarshaler); ok {
+			return true, u.UnmarshalText(s)
+		}
+		if u, ok := dest.(encoding.BinaryUnmarshaler); ok {
+			return true, u.UnmarshalBinary(s)
+		}
+	}
+	return false, nil
+}
+
 // Value returns a driver Value.
 // This method is required from database/sql/driver.Valuer interface.
+//
+// If the Option is None, this returns nil (i.e. SQL NULL). Otherwise, this converts the value by driver.DefaultParameterConverter,
+// and if that rejects the value and T implements encoding.TextMarshaler, this returns the marshaled text as a string.
+// If you'd like to store the value with another encoding (e.g. JSON), please consider using WithValueEncoder().
 func (o Option[T]) Value() (driver.Value, error) {
+	return o.valueWith(nil)
+}
+
+// valueWith converts the value into a driver Value. If the encoder is not nil, that is used as the last resort.
+func (o Option[T]) valueWith(encoder ValueEncoder) (driver.Value, error) {
 	if o.IsNone() {
 		return nil, nil
 	}
-	return driver.DefaultParameterConverter.ConvertValue(o.Unwrap())
+
+	v := o.Unwrap()
+	converted, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err == nil {
+		return converted, nil
+	}
+
+	if marshaler, ok := any(v).(encoding.TextMarshaler); ok {
+		text, marshalErr := marshaler.MarshalText()
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		return string(text), nil
+	}
+
+	if encoder != nil {
+		return encoder(v)
+	}
+	return nil, err
+}
+
+// ValueEncoder is a function that encodes a value that is not supported by database drivers into a driver Value.
+type ValueEncoder func(v any) (driver.Value, error)
+
+// JSONValueEncoder is a ValueEncoder that encodes the value as a JSON string.
+func JSONValueEncoder(v any) (driver.Value, error) {
+	marshal, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(marshal), nil
+}
+
+// EncodedValuer is a driver.Valuer that wraps an Option with a ValueEncoder.
+// This makes it explicit per column (or per query argument) that the value is encoded by the encoder when the driver doesn't support the value.
+type EncodedValuer[T any] struct {
+	option  Option[T]
+	encoder ValueEncoder
+}
+
+// WithValueEncoder wraps the Option with the ValueEncoder.
+// The returned value converts the Option in the same manner as Option#Value(), but if the value can't be converted by that, this uses the encoder instead of returning an error.
+func WithValueEncoder[T any](option Option[T], encoder ValueEncoder) EncodedValuer[T] {
+	return EncodedValuer[T]{
+		option:  option,
+		encoder: encoder,
+	}
+}
+
+// Value returns a driver Value.
+// This method is required from database/sql/driver.Valuer interface.
+func (e EncodedValuer[T]) Value() (driver.Value, error) {
+	return e.option.valueWith(e.encoder)
 }
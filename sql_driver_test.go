@@ -3,7 +3,10 @@ package optional
 import (
 	"database/sql"
 	"database/sql/driver"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -231,3 +234,227 @@ func TestOption_SQLValuer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, maybeName.IsNone())
 }
+
+type scanTestColor int
+
+const (
+	scanTestColorRed scanTestColor = iota + 1
+	scanTestColorBlue
+)
+
+func (c *scanTestColor) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red":
+		*c = scanTestColorRed
+	case "blue":
+		*c = scanTestColorBlue
+	default:
+		return fmt.Errorf("unknown color: %s", text)
+	}
+	return nil
+}
+
+type scanTestPoint struct {
+	X, Y byte
+}
+
+func (p *scanTestPoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return fmt.Errorf("invalid point length: %d", len(data))
+	}
+	p.X, p.Y = data[0], data[1]
+	return nil
+}
+
+type scanTestUpper string
+
+func (u *scanTestUpper) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type: %T", src)
+	}
+	*u = scanTestUpper(strings.ToUpper(s))
+	return nil
+}
+
+// UnmarshalText must not be used because sql.Scanner has priority.
+func (u *scanTestUpper) UnmarshalText(text []byte) error {
+	return errors.New("must not be called")
+}
+
+func TestOption_Scan_withInterfaces(t *testing.T) {
+	{
+		var o Option[scanTestColor]
+		assert.NoError(t, o.Scan("blue"))
+		assert.Equal(t, Some[scanTestColor](scanTestColorBlue), o)
+		assert.NoError(t, o.Scan([]byte("red")))
+		assert.Equal(t, Some[scanTestColor](scanTestColorRed), o)
+		assert.NoError(t, o.Scan(nil))
+		assert.True(t, o.IsNone())
+		assert.Error(t, o.Scan("green"))
+	}
+
+	{
+		var o Option[scanTestPoint]
+		assert.NoError(t, o.Scan([]byte{1, 2}))
+		assert.Equal(t, Some[scanTestPoint](scanTestPoint{X: 1, Y: 2}), o)
+		assert.Error(t, o.Scan("not bytes"))
+	}
+
+	{
+		var o Option[scanTestUpper]
+		assert.NoError(t, o.Scan("foo"))
+		assert.Equal(t, Some[scanTestUpper]("FOO"), o)
+	}
+
+	{
+		// time.Time implements encoding.TextUnmarshaler, so this can be scanned from the text
+		var o Option[time.Time]
+		assert.NoError(t, o.Scan("2006-01-02T15:04:05Z"))
+		assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), o.Unwrap())
+	}
+}
+
+func TestOption_SQLScan_withInterfaces(t *testing.T) {
+	tmpfile, err := os.CreateTemp(os.TempDir(), "testdb")
+	assert.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	sqlStmt := "CREATE TABLE test_table (id INTEGER NOT NULL PRIMARY KEY, color TEXT, point BLOB, name TEXT);"
+	_, err = db.Exec(sqlStmt)
+	assert.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO test_table(id, color, point, name) VALUES (1, 'red', x'0102', 'foo'), (2, NULL, NULL, NULL), (3, 'green', NULL, NULL)")
+	assert.NoError(t, err)
+
+	var (
+		maybeColor Option[scanTestColor]
+		maybePoint Option[scanTestPoint]
+		maybeName  Option[scanTestUpper]
+	)
+
+	row := db.QueryRow("SELECT color, point, name FROM test_table WHERE id = 1")
+	err = row.Scan(&maybeColor, &maybePoint, &maybeName)
+	assert.NoError(t, err)
+	assert.Equal(t, Some[scanTestColor](scanTestColorRed), maybeColor)
+	assert.Equal(t, Some[scanTestPoint](scanTestPoint{X: 1, Y: 2}), maybePoint)
+	assert.Equal(t, Some[scanTestUpper]("FOO"), maybeName)
+
+	row = db.QueryRow("SELECT color, point, name FROM test_table WHERE id = 2")
+	err = row.Scan(&maybeColor, &maybePoint, &maybeName)
+	assert.NoError(t, err)
+	assert.True(t, maybeColor.IsNone())
+	assert.True(t, maybePoint.IsNone())
+	assert.True(t, maybeName.IsNone())
+
+	row = db.QueryRow("SELECT color FROM test_table WHERE id = 3")
+	err = row.Scan(&maybeColor)
+	assert.Error(t, err)
+}
+
+type valueTestVersion struct {
+	Major, Minor int
+}
+
+func (v valueTestVersion) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("v%d.%d", v.Major, v.Minor)), nil
+}
+
+type valueTestFailingMarshaler struct{}
+
+func (valueTestFailingMarshaler) MarshalText() ([]byte, error) {
+	return nil, errors.New("failed to marshal")
+}
+
+func TestOption_Value_withTextMarshaler(t *testing.T) {
+	v, err := Some[valueTestVersion](valueTestVersion{Major: 1, Minor: 2}).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2", v)
+
+	_, err = Some[valueTestFailingMarshaler](valueTestFailingMarshaler{}).Value()
+	assert.EqualError(t, err, "failed to marshal")
+
+	_, err = Some[[]string]([]string{"a"}).Value()
+	assert.Error(t, err)
+}
+
+func TestWithValueEncoder(t *testing.T) {
+	type ustruct struct {
+		A int `json:"a"`
+	}
+
+	{
+		v, err := WithValueEncoder(Some[ustruct](ustruct{A: 1}), JSONValueEncoder).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":1}`, v)
+	}
+
+	{
+		v, err := WithValueEncoder(Some[[]string]([]string{"a", "b"}), JSONValueEncoder).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `["a","b"]`, v)
+	}
+
+	{
+		v, err := WithValueEncoder(None[ustruct](), JSONValueEncoder).Value()
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	}
+
+	{
+		// driver-supported values and TextMarshaler have priority
+		v, err := WithValueEncoder(Some[int64](42), JSONValueEncoder).Value()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 42, v)
+
+		v, err = WithValueEncoder(Some[valueTestVersion](valueTestVersion{Major: 1}), JSONValueEncoder).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.0", v)
+	}
+
+	{
+		_, err := WithValueEncoder(Some[chan int](make(chan int)), JSONValueEncoder).Value()
+		assert.Error(t, err)
+	}
+}
+
+func TestWithValueEncoder_SQLValuer(t *testing.T) {
+	tmpfile, err := os.CreateTemp(os.TempDir(), "testdb")
+	assert.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	assert.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	_, err = db.Exec("CREATE TABLE test_table (id INTEGER NOT NULL PRIMARY KEY, tags TEXT, version TEXT);")
+	assert.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO test_table(id, tags, version) VALUES (?, ?, ?)", 1, WithValueEncoder(Some[[]string]([]string{"a", "b"}), JSONValueEncoder), Some[valueTestVersion](valueTestVersion{Major: 2, Minor: 1}))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO test_table(id, tags, version) VALUES (?, ?, ?)", 2, WithValueEncoder(None[[]string](), JSONValueEncoder), None[valueTestVersion]())
+	assert.NoError(t, err)
+
+	var (
+		maybeTags    Option[string]
+		maybeVersion Option[string]
+	)
+
+	row := db.QueryRow("SELECT tags, version FROM test_table WHERE id = 1")
+	err = row.Scan(&maybeTags, &maybeVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, Some[string](`["a","b"]`), maybeTags)
+	assert.Equal(t, Some[string]("v2.1"), maybeVersion)
+
+	row = db.QueryRow("SELECT tags, version FROM test_table WHERE id = 2")
+	err = row.Scan(&maybeTags, &maybeVersion)
+	assert.NoError(t, err)
+	assert.True(t, maybeTags.IsNone())
+	assert.True(t, maybeVersion.IsNone())
+}
@@ -0,0 +1,174 @@
+package optional
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// FromSQLNull converts the sql.Null[T] value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNull[T any](n sql.Null[T]) Option[T] {
+	if !n.Valid {
+		return None[T]()
+	}
+	return Some[T](n.V)
+}
+
+// ToSQLNull converts the Option value into the sql.Null[T] value. If the Option is None, this returns the invalid value.
+func ToSQLNull[T any](o Option[T]) sql.Null[T] {
+	return sql.Null[T]{
+		V:     o.Unwrap(),
+		Valid: o.IsSome(),
+	}
+}
+
+// FromSQLNullString converts the sql.NullString value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullString(n sql.NullString) Option[string] {
+	return FromSQLNull(sql.Null[string]{V: n.String, Valid: n.Valid})
+}
+
+// ToSQLNullString converts the Option value into the sql.NullString value. If the Option is None, this returns the invalid value.
+func ToSQLNullString(o Option[string]) sql.NullString {
+	return sql.NullString{String: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullInt64 converts the sql.NullInt64 value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullInt64(n sql.NullInt64) Option[int64] {
+	return FromSQLNull(sql.Null[int64]{V: n.Int64, Valid: n.Valid})
+}
+
+// ToSQLNullInt64 converts the Option value into the sql.NullInt64 value. If the Option is None, this returns the invalid value.
+func ToSQLNullInt64(o Option[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullInt32 converts the sql.NullInt32 value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullInt32(n sql.NullInt32) Option[int32] {
+	return FromSQLNull(sql.Null[int32]{V: n.Int32, Valid: n.Valid})
+}
+
+// ToSQLNullInt32 converts the Option value into the sql.NullInt32 value. If the Option is None, this returns the invalid value.
+func ToSQLNullInt32(o Option[int32]) sql.NullInt32 {
+	return sql.NullInt32{Int32: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullInt16 converts the sql.NullInt16 value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullInt16(n sql.NullInt16) Option[int16] {
+	return FromSQLNull(sql.Null[int16]{V: n.Int16, Valid: n.Valid})
+}
+
+// ToSQLNullInt16 converts the Option value into the sql.NullInt16 value. If the Option is None, this returns the invalid value.
+func ToSQLNullInt16(o Option[int16]) sql.NullInt16 {
+	return sql.NullInt16{Int16: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullByte converts the sql.NullByte value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullByte(n sql.NullByte) Option[byte] {
+	return FromSQLNull(sql.Null[byte]{V: n.Byte, Valid: n.Valid})
+}
+
+// ToSQLNullByte converts the Option value into the sql.NullByte value. If the Option is None, this returns the invalid value.
+func ToSQLNullByte(o Option[byte]) sql.NullByte {
+	return sql.NullByte{Byte: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullFloat64 converts the sql.NullFloat64 value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullFloat64(n sql.NullFloat64) Option[float64] {
+	return FromSQLNull(sql.Null[float64]{V: n.Float64, Valid: n.Valid})
+}
+
+// ToSQLNullFloat64 converts the Option value into the sql.NullFloat64 value. If the Option is None, this returns the invalid value.
+func ToSQLNullFloat64(o Option[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullBool converts the sql.NullBool value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullBool(n sql.NullBool) Option[bool] {
+	return FromSQLNull(sql.Null[bool]{V: n.Bool, Valid: n.Valid})
+}
+
+// ToSQLNullBool converts the Option value into the sql.NullBool value. If the Option is None, this returns the invalid value.
+func ToSQLNullBool(o Option[bool]) sql.NullBool {
+	return sql.NullBool{Bool: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// FromSQLNullTime converts the sql.NullTime value into the Option value. If the given value is not valid, this returns None.
+func FromSQLNullTime(n sql.NullTime) Option[time.Time] {
+	return FromSQLNull(sql.Null[time.Time]{V: n.Time, Valid: n.Valid})
+}
+
+// ToSQLNullTime converts the Option value into the sql.NullTime value. If the Option is None, this returns the invalid value.
+func ToSQLNullTime(o Option[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: o.Unwrap(), Valid: o.IsSome()}
+}
+
+// sqlNullJSONValueKeys are the JSON object keys of the value field of sql.Null[T] and sql.NullXxx types.
+var sqlNullJSONValueKeys = map[string]struct{}{
+	"V": {}, "String": {}, "Int64": {}, "Int32": {}, "Int16": {}, "Byte": {}, "Float64": {}, "Bool": {}, "Time": {},
+}
+
+// unmarshalSQLNullJSON decodes the JSON object that has the shape of the JSON-encoded sql.Null[T] and sql.NullXxx types,
+// e.g. `{"String":"foo","Valid":true}` and `{"V":0,"Valid":false}`, into the Option value.
+// The second return value reports whether the data has that shape and the value can be decoded or not.
+// This deals with only the types whose JSON representation is never an object (see acceptsSQLNullJSON), so the payload for the other types is decoded into T as it is.
+func unmarshalSQLNullJSON[T any](data []byte) (Option[T], bool) {
+	if !acceptsSQLNullJSON(reflect.TypeOf((*T)(nil)).Elem()) {
+		return nil, false
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) <= 0 || trimmed[0] != '{' {
+		return nil, false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+
+	var valid bool
+	if rawValid, ok := fields["Valid"]; !ok || json.Unmarshal(rawValid, &valid) != nil {
+		return nil, false
+	}
+
+	var rawValue json.RawMessage
+	for key, raw := range fields {
+		if key == "Valid" {
+			continue
+		}
+		if _, ok := sqlNullJSONValueKeys[key]; !ok || rawValue != nil {
+			return nil, false
+		}
+		rawValue = raw
+	}
+
+	if !valid {
+		return None[T](), true
+	}
+	if rawValue == nil {
+		return nil, false
+	}
+
+	var v T
+	if err := json.Unmarshal(rawValue, &v); err != nil {
+		return nil, false
+	}
+	return Some[T](v), true
+}
+
+// acceptsSQLNullJSON returns whether the legacy JSON payload of sql.Null[T] and sql.NullXxx types can be decoded into the type.
+// That is limited to the boolean, numeric and string types, and time.Time; these never take a JSON object, so the payload can't be confused with the value of the type.
+func acceptsSQLNullJSON(typ reflect.Type) bool {
+	if typ == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,153 @@
+package optional
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSQLNull(t *testing.T) {
+	assert.Equal(t, Some[int](123), FromSQLNull(sql.Null[int]{V: 123, Valid: true}))
+	assert.True(t, FromSQLNull(sql.Null[int]{V: 123, Valid: false}).IsNone())
+}
+
+func TestToSQLNull(t *testing.T) {
+	assert.Equal(t, sql.Null[int]{V: 123, Valid: true}, ToSQLNull(Some[int](123)))
+	assert.Equal(t, sql.Null[int]{}, ToSQLNull(None[int]()))
+}
+
+func TestSQLNullXxxConversions(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, Some[string]("foo"), FromSQLNullString(sql.NullString{String: "foo", Valid: true}))
+	assert.True(t, FromSQLNullString(sql.NullString{}).IsNone())
+	assert.Equal(t, sql.NullString{String: "foo", Valid: true}, ToSQLNullString(Some[string]("foo")))
+	assert.Equal(t, sql.NullString{}, ToSQLNullString(None[string]()))
+
+	assert.Equal(t, Some[int64](1), FromSQLNullInt64(sql.NullInt64{Int64: 1, Valid: true}))
+	assert.True(t, FromSQLNullInt64(sql.NullInt64{}).IsNone())
+	assert.Equal(t, sql.NullInt64{Int64: 1, Valid: true}, ToSQLNullInt64(Some[int64](1)))
+	assert.Equal(t, sql.NullInt64{}, ToSQLNullInt64(None[int64]()))
+
+	assert.Equal(t, Some[int32](1), FromSQLNullInt32(sql.NullInt32{Int32: 1, Valid: true}))
+	assert.True(t, FromSQLNullInt32(sql.NullInt32{}).IsNone())
+	assert.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, ToSQLNullInt32(Some[int32](1)))
+	assert.Equal(t, sql.NullInt32{}, ToSQLNullInt32(None[int32]()))
+
+	assert.Equal(t, Some[int16](1), FromSQLNullInt16(sql.NullInt16{Int16: 1, Valid: true}))
+	assert.True(t, FromSQLNullInt16(sql.NullInt16{}).IsNone())
+	assert.Equal(t, sql.NullInt16{Int16: 1, Valid: true}, ToSQLNullInt16(Some[int16](1)))
+	assert.Equal(t, sql.NullInt16{}, ToSQLNullInt16(None[int16]()))
+
+	assert.Equal(t, Some[byte](1), FromSQLNullByte(sql.NullByte{Byte: 1, Valid: true}))
+	assert.True(t, FromSQLNullByte(sql.NullByte{}).IsNone())
+	assert.Equal(t, sql.NullByte{Byte: 1, Valid: true}, ToSQLNullByte(Some[byte](1)))
+	assert.Equal(t, sql.NullByte{}, ToSQLNullByte(None[byte]()))
+
+	assert.Equal(t, Some[float64](1.5), FromSQLNullFloat64(sql.NullFloat64{Float64: 1.5, Valid: true}))
+	assert.True(t, FromSQLNullFloat64(sql.NullFloat64{}).IsNone())
+	assert.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, ToSQLNullFloat64(Some[float64](1.5)))
+	assert.Equal(t, sql.NullFloat64{}, ToSQLNullFloat64(None[float64]()))
+
+	assert.Equal(t, Some[bool](true), FromSQLNullBool(sql.NullBool{Bool: true, Valid: true}))
+	assert.True(t, FromSQLNullBool(sql.NullBool{}).IsNone())
+	assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, ToSQLNullBool(Some[bool](true)))
+	assert.Equal(t, sql.NullBool{}, ToSQLNullBool(None[bool]()))
+
+	assert.Equal(t, Some[time.Time](now), FromSQLNullTime(sql.NullTime{Time: now, Valid: true}))
+	assert.True(t, FromSQLNullTime(sql.NullTime{}).IsNone())
+	assert.Equal(t, sql.NullTime{Time: now, Valid: true}, ToSQLNullTime(Some[time.Time](now)))
+	assert.Equal(t, sql.NullTime{}, ToSQLNullTime(None[time.Time]()))
+}
+
+func TestOption_UnmarshalJSON_legacySQLNullPayload(t *testing.T) {
+	{
+		legacy, err := json.Marshal(sql.NullString{String: "foo", Valid: true})
+		assert.NoError(t, err)
+		var o Option[string]
+		assert.NoError(t, json.Unmarshal(legacy, &o))
+		assert.Equal(t, Some[string]("foo"), o)
+	}
+
+	{
+		legacy, err := json.Marshal(sql.NullInt64{})
+		assert.NoError(t, err)
+		o := Some[int64](123)
+		assert.NoError(t, json.Unmarshal(legacy, &o))
+		assert.True(t, o.IsNone())
+	}
+
+	{
+		now := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+		legacy, err := json.Marshal(sql.NullTime{Time: now, Valid: true})
+		assert.NoError(t, err)
+		var o Option[time.Time]
+		assert.NoError(t, json.Unmarshal(legacy, &o))
+		assert.Equal(t, Some[time.Time](now), o)
+	}
+
+	{
+		legacy, err := json.Marshal(sql.Null[float64]{V: 1.5, Valid: true})
+		assert.NoError(t, err)
+		var o Option[float64]
+		assert.NoError(t, json.Unmarshal(legacy, &o))
+		assert.Equal(t, Some[float64](1.5), o)
+	}
+
+	{
+		type JSONStruct struct {
+			Val Option[int] `json:"val"`
+		}
+		var s JSONStruct
+		assert.NoError(t, json.Unmarshal([]byte(`{"val":{"Int64":42,"Valid":true}}`), &s))
+		assert.Equal(t, Some[int](42), s.Val)
+	}
+}
+
+func TestOption_UnmarshalJSON_legacySQLNullPayloadShouldNotAffectStructValue(t *testing.T) {
+	type WithValid struct {
+		String string
+		Valid  bool
+	}
+
+	var o Option[WithValid]
+	assert.NoError(t, json.Unmarshal([]byte(`{"String":"foo","Valid":false}`), &o))
+	assert.Equal(t, Some[WithValid](WithValid{String: "foo", Valid: false}), o)
+
+	var ns Option[sql.NullString]
+	assert.NoError(t, json.Unmarshal([]byte(`{"String":"foo","Valid":true}`), &ns))
+	assert.Equal(t, Some[sql.NullString](sql.NullString{String: "foo", Valid: true}), ns)
+
+	var a Option[any]
+	assert.NoError(t, json.Unmarshal([]byte(`{"String":"foo","Valid":true}`), &a))
+	assert.Equal(t, Some[any](map[string]any{"String": "foo", "Valid": true}), a)
+
+	var m Option[map[string]any]
+	assert.NoError(t, json.Unmarshal([]byte(`{"V":1,"Valid":false}`), &m))
+	assert.Equal(t, Some[map[string]any](map[string]any{"V": float64(1), "Valid": false}), m)
+
+	type Point struct {
+		X int
+		Y int
+	}
+	var p Option[Point]
+	assert.NoError(t, json.Unmarshal([]byte(`{"V":{"X":1,"Y":2},"Valid":false}`), &p))
+	assert.Equal(t, Some[Point](Point{}), p)
+}
+
+func TestOption_UnmarshalJSON_invalidLegacySQLNullPayload(t *testing.T) {
+	for _, input := range []string{
+		`{"Int64":1}`,
+		`{"Valid":"true","Int64":1}`,
+		`{"Valid":true}`,
+		`{"Valid":true,"Int64":"one"}`,
+		`{"Valid":true,"Int64":1,"Int32":1}`,
+		`{"Valid":true,"Unknown":1}`,
+	} {
+		var o Option[int]
+		assert.Error(t, json.Unmarshal([]byte(input), &o), input)
+	}
+}
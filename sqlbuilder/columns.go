@@ -0,0 +1,80 @@
+package sqlbuilder
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+// columnField is a struct field that is mapped to a column by `db` struct tag.
+type columnField struct {
+	column   string
+	options  []string
+	value    reflect.Value
+	isOption bool
+}
+
+func (f columnField) hasOption(option string) bool {
+	for _, o := range f.options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// columnFields returns the fields of the struct value that have `db:"column_name[,option...]"` struct tag, in the order of the declaration.
+// The fields of the embedded structs are also returned.
+func columnFields(structValue reflect.Value) []columnField {
+	var fields []columnField
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, hasTag := field.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		if hasTag && field.IsExported() {
+			name, options, _ := strings.Cut(tag, ",")
+			var opts []string
+			if options != "" {
+				opts = strings.Split(options, ",")
+			}
+			fields = append(fields, columnField{
+				column:   name,
+				options:  opts,
+				value:    structValue.Field(i),
+				isOption: optiontype.Is(field.Type),
+			})
+			continue
+		}
+
+		if field.Anonymous {
+			fieldValue := structValue.Field(i)
+			if fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				fields = append(fields, columnFields(fieldValue)...)
+			}
+		}
+	}
+	return fields
+}
+
+// indirectStruct returns the struct value from the struct or the non-nil pointer to a struct.
+func indirectStruct(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
@@ -0,0 +1,157 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+var (
+	// ErrNoRowsToInsert represents the error that is raised when there is no row to insert.
+	ErrNoRowsToInsert = errors.New("no rows to insert")
+)
+
+// Statement is a pair of the parameterized SQL and the arguments.
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+// insertValue is a value of a column in the row to insert.
+type insertValue struct {
+	column string
+	field  reflect.Value
+	// isDefault is true if the value is written as DEFAULT keyword.
+	isDefault bool
+}
+
+// Insert builds the INSERT statement (e.g. `INSERT INTO table (a, b) VALUES (?, DEFAULT)`) and the arguments from the row struct.
+//
+// The row must be a struct (or a pointer to that) that has the fields with `db:"column_name[,default]"` struct tag:
+//   - non-Option field: the column is always inserted with the value
+//   - optional.Option[T] field: Some[T] inserts the value (that is bound as the result of Option#Value()). None leaves out the column by default, or emits DEFAULT keyword if the field has `default` tag option (e.g. `db:"created_at,default"`)
+//   - optional.Option[optional.Option[T]] field: same as above, but Some[None[T]] inserts NULL explicitly
+//
+// The fields of the embedded structs are also dealt with. If all the columns are left out, this builds `INSERT INTO table DEFAULT VALUES`.
+func Insert(table string, row any, placeholder PlaceholderStyle) (string, []any, error) {
+	values, err := insertValuesOf(row)
+	if err != nil {
+		return "", nil, err
+	}
+
+	b := newBuilder(placeholder)
+	appendInsert(b, table, [][]insertValue{values})
+	query, args := b.build()
+	return query, args, nil
+}
+
+// BulkInsert builds the multi-row INSERT statements from the row structs, in the same manner as Insert.
+// The rows that have the same column set are grouped into a single statement (e.g. `INSERT INTO table (a, b) VALUES (?, ?), (?, ?)`),
+// so this returns a statement per column set, in the order of the first appearance of each column set.
+// The rows whose columns are all left out are inserted by `INSERT INTO table DEFAULT VALUES` for each row.
+func BulkInsert[T any](table string, rows []T, placeholder PlaceholderStyle) ([]Statement, error) {
+	if len(rows) <= 0 {
+		return nil, ErrNoRowsToInsert
+	}
+
+	var (
+		groupKeys []string
+		groups    = make(map[string][][]insertValue)
+	)
+	for i, row := range rows {
+		values, err := insertValuesOf(row)
+		if err != nil {
+			return nil, err
+		}
+
+		key := columnSetKey(values)
+		if len(values) <= 0 {
+			// `DEFAULT VALUES` can't be used with multiple rows; make the key unique to build the statement for each row
+			key = "\x00" + strconv.Itoa(i)
+		}
+		if _, exists := groups[key]; !exists {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], values)
+	}
+
+	statements := make([]Statement, 0, len(groupKeys))
+	for _, key := range groupKeys {
+		b := newBuilder(placeholder)
+		appendInsert(b, table, groups[key])
+		query, args := b.build()
+		statements = append(statements, Statement{SQL: query, Args: args})
+	}
+	return statements, nil
+}
+
+func insertValuesOf(row any) ([]insertValue, error) {
+	rv, ok := indirectStruct(row)
+	if !ok {
+		return nil, ErrInvalidStruct
+	}
+
+	var values []insertValue
+	for _, field := range columnFields(rv) {
+		if field.isOption && !optiontype.IsSome(field.value) {
+			if field.hasOption("default") {
+				values = append(values, insertValue{column: field.column, isDefault: true})
+			}
+			continue
+		}
+		values = append(values, insertValue{column: field.column, field: field.value})
+	}
+	return values, nil
+}
+
+func columnSetKey(values []insertValue) string {
+	columns := make([]string, len(values))
+	for i, v := range values {
+		columns[i] = v.column
+	}
+	return strings.Join(columns, ",")
+}
+
+// appendInsert writes the INSERT statement for the rows. All the rows must have the same column set.
+func appendInsert(b *builder, table string, rows [][]insertValue) {
+	b.write("INSERT INTO ")
+	b.write(table)
+
+	if len(rows[0]) <= 0 {
+		b.write(" DEFAULT VALUES")
+		return
+	}
+
+	b.write(" (")
+	for i, v := range rows[0] {
+		if i > 0 {
+			b.write(", ")
+		}
+		b.write(v.column)
+	}
+	b.write(") VALUES ")
+
+	for i, row := range rows {
+		if i > 0 {
+			b.write(", ")
+		}
+		b.write("(")
+		for j, v := range row {
+			if j > 0 {
+				b.write(", ")
+			}
+			switch {
+			case v.isDefault:
+				b.write("DEFAULT")
+			case optiontype.Is(v.field.Type()):
+				appendOptionValue(b, v.field)
+			default:
+				b.bind(v.field.Interface())
+			}
+		}
+		b.write(")")
+	}
+}
@@ -0,0 +1,176 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUserRow struct {
+	ID     int64                                 `db:"id"`
+	Name   string                                `db:"name"`
+	Status optional.Option[string]               `db:"status,default"`
+	Age    optional.Option[optional.Option[int]] `db:"age"`
+}
+
+func TestInsert(t *testing.T) {
+	query, args, err := Insert("users", testUserRow{ID: 1, Name: "foo"}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name, status) VALUES (?, ?, DEFAULT)", query)
+	assert.Equal(t, []any{int64(1), "foo"}, args)
+
+	query, args, err = Insert("users", &testUserRow{ID: 2, Name: "bar", Status: optional.Some[string]("inactive"), Age: SetNull[int]()}, Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name, status, age) VALUES ($1, $2, $3, NULL)", query)
+	assert.Equal(t, []any{int64(2), "bar", "inactive"}, args)
+
+	query, args, err = Insert("users", testUserRow{ID: 3, Name: "baz", Age: SetValue[int](20)}, Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name, status, age) VALUES ($1, $2, DEFAULT, $3)", query)
+	assert.Equal(t, []any{int64(3), "baz", int64(20)}, args)
+}
+
+func TestInsert_defaultValues(t *testing.T) {
+	type row struct {
+		Name optional.Option[string] `db:"name"`
+	}
+
+	query, args, err := Insert("users", row{}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users DEFAULT VALUES", query)
+	assert.Nil(t, args)
+
+	_, _, err = Insert("users", 1, Question)
+	assert.ErrorIs(t, err, ErrInvalidStruct)
+}
+
+func TestBulkInsert(t *testing.T) {
+	statements, err := BulkInsert("users", []testUserRow{
+		{ID: 1, Name: "foo"},
+		{ID: 2, Name: "bar", Age: SetValue[int](20)},
+		{ID: 3, Name: "baz", Status: optional.Some[string]("inactive")},
+		{ID: 4, Name: "qux", Age: SetNull[int]()},
+	}, Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, []Statement{
+		{
+			SQL:  "INSERT INTO users (id, name, status) VALUES ($1, $2, DEFAULT), ($3, $4, $5)",
+			Args: []any{int64(1), "foo", int64(3), "baz", "inactive"},
+		},
+		{
+			SQL:  "INSERT INTO users (id, name, status, age) VALUES ($1, $2, DEFAULT, $3), ($4, $5, DEFAULT, NULL)",
+			Args: []any{int64(2), "bar", int64(20), int64(4), "qux"},
+		},
+	}, statements)
+}
+
+func TestBulkInsert_omittedColumns(t *testing.T) {
+	type row struct {
+		Name   optional.Option[string] `db:"name"`
+		Status optional.Option[string] `db:"status"`
+	}
+
+	statements, err := BulkInsert("users", []row{
+		{Name: optional.Some[string]("foo")},
+		{},
+		{Name: optional.Some[string]("bar"), Status: optional.Some[string]("inactive")},
+		{Name: optional.Some[string]("baz")},
+		{},
+	}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, []Statement{
+		{SQL: "INSERT INTO users (name) VALUES (?), (?)", Args: []any{"foo", "baz"}},
+		{SQL: "INSERT INTO users DEFAULT VALUES"},
+		{SQL: "INSERT INTO users (name, status) VALUES (?, ?)", Args: []any{"bar", "inactive"}},
+		{SQL: "INSERT INTO users DEFAULT VALUES"},
+	}, statements)
+
+	_, err = BulkInsert[row]("users", nil, Question)
+	assert.ErrorIs(t, err, ErrNoRowsToInsert)
+}
+
+func TestBulkInsert_SQL(t *testing.T) {
+	db := openTestDB(t)
+
+	// SQLite doesn't support DEFAULT keyword in VALUES, so this leaves out the columns instead
+	type row struct {
+		ID     int64                                 `db:"id"`
+		Name   string                                `db:"name"`
+		Status optional.Option[string]               `db:"status"`
+		Age    optional.Option[optional.Option[int]] `db:"age"`
+	}
+	statements, err := BulkInsert("users", []row{
+		{ID: 1, Name: "foo"},
+		{ID: 2, Name: "bar", Status: optional.Some[string]("inactive"), Age: SetValue[int](30)},
+		{ID: 3, Name: "baz", Status: optional.Some[string]("inactive"), Age: SetNull[int]()},
+	}, Question)
+	assert.NoError(t, err)
+	assert.Len(t, statements, 2)
+	for _, stmt := range statements {
+		_, err := db.Exec(stmt.SQL, stmt.Args...)
+		assert.NoError(t, err)
+	}
+
+	rows, err := db.Query("SELECT status, age FROM users ORDER BY id")
+	assert.NoError(t, err)
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var got []optional.Pair[string, optional.Option[int]]
+	for rows.Next() {
+		var (
+			status string
+			age    optional.Option[int]
+		)
+		assert.NoError(t, rows.Scan(&status, &age))
+		got = append(got, optional.Pair[string, optional.Option[int]]{Value1: status, Value2: age})
+	}
+	assert.Equal(t, []optional.Pair[string, optional.Option[int]]{
+		{Value1: "active", Value2: optional.None[int]()},
+		{Value1: "inactive", Value2: optional.Some[int](30)},
+		{Value1: "inactive", Value2: optional.None[int]()},
+	}, got)
+}
+
+func TestInsert_SQL_textMarshaler(t *testing.T) {
+	db := openTestDB(t)
+
+	type row struct {
+		ID     int64                                          `db:"id"`
+		Name   optional.Option[testFullName]                  `db:"name"`
+		Status optional.Option[optional.Option[testFullName]] `db:"status"`
+	}
+	query, args, err := Insert("users", row{
+		ID:     1,
+		Name:   optional.Some(testFullName{First: "foo", Last: "bar"}),
+		Status: SetValue(testFullName{First: "in", Last: "active"}),
+	}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(1), "foo bar", "in active"}, args)
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	statements, err := BulkInsert("users", []row{
+		{ID: 2, Name: optional.Some(testFullName{First: "baz", Last: "qux"})},
+	}, Question)
+	assert.NoError(t, err)
+	for _, stmt := range statements {
+		_, err := db.Exec(stmt.SQL, stmt.Args...)
+		assert.NoError(t, err)
+	}
+
+	var names []string
+	rows, err := db.Query("SELECT name || '/' || status FROM users ORDER BY id")
+	assert.NoError(t, err)
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var name string
+		assert.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"foo bar/in active", "baz qux/active"}, names)
+}
@@ -0,0 +1,167 @@
+package sqlbuilder
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/moznion/go-optional"
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+var (
+	// ErrUnknownParameter represents the error that is raised when the named parameter doesn't have the corresponding field.
+	ErrUnknownParameter = errors.New("unknown named parameter")
+	// ErrMissingParameter represents the error that is raised when the required named parameter is None.
+	ErrMissingParameter = errors.New("missing required named parameter")
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// Named rewrites the named parameters (e.g. `:name`) in the query into the placeholders, and binds the values of the params struct.
+//
+// The params must be a struct (or a pointer to that) that has the fields with `db:"parameter_name[,required]"` struct tag:
+//   - non-Option field: the value is bound as it is
+//   - optional.Option[T] field: Some[T] binds the result of Option#Value(). None binds NULL, or that is an error (ErrMissingParameter) if the field has `required` tag option
+//
+// The slice value (except for []byte and driver.Valuer implementations), including the contained value of optional.Option[[]T], is expanded into the list of the placeholders,
+// so that can be used with IN operator (e.g. `id IN (:ids)` becomes `id IN (?, ?, ?)`). The empty slice is expanded into NULL, so that IN operator never matches.
+// The elements of optional.Option[[]T] are bound as the result of Option#Value(), and the elements of the non-Option slice are bound as they are.
+//
+// The parameter that doesn't have the corresponding field is an error (ErrUnknownParameter).
+// The colons in the string literals, the quoted identifiers and the comments are left as they are, and so is PostgreSQL style type cast (i.e. `::type`).
+func Named(query string, params any, placeholder PlaceholderStyle) (string, []any, error) {
+	rv, ok := indirectStruct(params)
+	if !ok {
+		return "", nil, ErrInvalidStruct
+	}
+
+	fields := make(map[string]columnField)
+	for _, f := range columnFields(rv) {
+		if _, exists := fields[f.column]; !exists {
+			fields[f.column] = f
+		}
+	}
+
+	b := newBuilder(placeholder)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.write(query[i:])
+				i = len(query)
+				continue
+			}
+			b.write(query[i : i+end+2])
+			i += end + 2
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.write(query[i : i+end])
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				b.write(query[i:])
+				i = len(query)
+				continue
+			}
+			b.write(query[i : i+end+4])
+			i += end + 4
+		case strings.HasPrefix(query[i:], "::"):
+			b.write("::")
+			i += 2
+		case c == ':' && i+1 < len(query) && isParameterNameStart(query[i+1]):
+			end := i + 2
+			for end < len(query) && isParameterNameChar(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+			f, ok := fields[name]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %s", ErrUnknownParameter, name)
+			}
+			err := bindParameter(b, name, f)
+			if err != nil {
+				return "", nil, err
+			}
+			i = end
+		default:
+			b.write(query[i : i+1])
+			i++
+		}
+	}
+
+	query, args := b.build()
+	return query, args, nil
+}
+
+func isParameterNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isParameterNameChar(c byte) bool {
+	return isParameterNameStart(c) || ('0' <= c && c <= '9')
+}
+
+func bindParameter(b *builder, name string, f columnField) error {
+	value := f.value
+	if !f.isOption {
+		if isExpandable(value.Type()) {
+			bindList(b, value, false)
+			return nil
+		}
+		b.bind(value.Interface())
+		return nil
+	}
+
+	if !optiontype.IsSome(value) {
+		if f.hasOption("required") {
+			return fmt.Errorf("%w: %s", ErrMissingParameter, name)
+		}
+		b.bind(nil)
+		return nil
+	}
+
+	if elem := optiontype.Elem(value); isExpandable(elem.Type()) {
+		bindList(b, elem, true)
+		return nil
+	}
+	v, err := value.Interface().(driver.Valuer).Value()
+	if err != nil {
+		return fmt.Errorf("failed to bind named parameter %s: %w", name, err)
+	}
+	b.bind(v)
+	return nil
+}
+
+// isExpandable returns whether the value of the type is expanded into the list of the placeholders or not.
+func isExpandable(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 && !typ.Implements(valuerType)
+}
+
+// bindList writes the placeholders for the elements of the list. If viaOption is true (i.e. the list is the contained value of optional.Option[[]T]),
+// each element is bound as the result of Option#Value() in the same manner as In.
+func bindList(b *builder, list reflect.Value, viaOption bool) {
+	if list.Len() <= 0 {
+		b.write("NULL")
+		return
+	}
+	for i := 0; i < list.Len(); i++ {
+		if i > 0 {
+			b.write(", ")
+		}
+		elem := list.Index(i).Interface()
+		if viaOption {
+			b.bindValuer(optional.Some(elem))
+			continue
+		}
+		b.bind(elem)
+	}
+}
@@ -0,0 +1,168 @@
+package sqlbuilder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type searchParams struct {
+	Status optional.Option[string]  `db:"status"`
+	MinAge optional.Option[int]     `db:"min_age"`
+	IDs    optional.Option[[]int64] `db:"ids"`
+	Limit  int                      `db:"limit"`
+}
+
+func TestNamed(t *testing.T) {
+	params := searchParams{
+		Status: optional.Some("active"),
+		MinAge: optional.None[int](),
+		IDs:    optional.Some([]int64{1, 2, 3}),
+		Limit:  10,
+	}
+
+	query, args, err := Named("SELECT id FROM users WHERE status = :status AND (age >= :min_age OR :min_age IS NULL) AND id IN (:ids) LIMIT :limit", params, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE status = ? AND (age >= ? OR ? IS NULL) AND id IN (?, ?, ?) LIMIT ?", query)
+	assert.Equal(t, []any{"active", nil, nil, int64(1), int64(2), int64(3), 10}, args)
+
+	query, args, err = Named("SELECT id FROM users WHERE status = :status AND id IN (:ids) LIMIT :limit", &params, Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE status = $1 AND id IN ($2, $3, $4) LIMIT $5", query)
+	assert.Equal(t, []any{"active", int64(1), int64(2), int64(3), 10}, args)
+}
+
+func TestNamed_bindsOptionValue(t *testing.T) {
+	type params struct {
+		Count   optional.Option[int]                  `db:"count"`
+		At      optional.Option[time.Time]            `db:"at"`
+		Nested  optional.Option[optional.Option[int]] `db:"nested"`
+		Avatar  optional.Option[[]byte]               `db:"avatar"`
+		Numbers []int                                 `db:"numbers"`
+	}
+
+	at := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	query, args, err := Named(":count, :at, :nested, :avatar, :numbers", params{
+		Count:   optional.Some(1),
+		At:      optional.Some(at),
+		Nested:  optional.Some(optional.None[int]()),
+		Avatar:  optional.Some([]byte{1, 2}),
+		Numbers: []int{4, 5},
+	}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "?, ?, ?, ?, ?, ?", query)
+	// Option#Value() converts the value into the driver.Value
+	assert.Equal(t, []any{int64(1), at, nil, []byte{1, 2}, 4, 5}, args)
+}
+
+func TestNamed_bindsOptionListElementValue(t *testing.T) {
+	type params struct {
+		Names optional.Option[[]testFullName] `db:"names"`
+		Name  optional.Option[testFullName]   `db:"name"`
+	}
+
+	query, args, err := Named("a IN (:names) AND b = :name", params{
+		Names: optional.Some([]testFullName{{First: "foo", Last: "bar"}, {First: "baz", Last: "qux"}}),
+		Name:  optional.Some(testFullName{First: "x", Last: "y"}),
+	}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "a IN (?, ?) AND b = ?", query)
+	assert.Equal(t, []any{"foo bar", "baz qux", "x y"}, args)
+
+	// the same as In
+	_, inArgs := Build(In("a", optional.Some([]testFullName{{First: "foo", Last: "bar"}})), Question)
+	assert.Equal(t, []any{"foo bar"}, inArgs)
+}
+
+func TestNamed_emptyList(t *testing.T) {
+	query, args, err := Named("SELECT id FROM users WHERE id IN (:ids)", searchParams{IDs: optional.Some([]int64{})}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id IN (NULL)", query)
+	assert.Nil(t, args)
+
+	query, args, err = Named("SELECT id FROM users WHERE id IN (:ids)", searchParams{}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id IN (?)", query)
+	assert.Equal(t, []any{nil}, args)
+}
+
+func TestNamed_ignoresLiteralsAndComments(t *testing.T) {
+	query, args, err := Named(`SELECT ':status', ":status", `+"`:status`"+`, age::text -- :status
+		/* :status */ FROM users WHERE status = :status AND note = 'it''s :status'`, searchParams{Status: optional.Some("active")}, Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT ':status', ":status", `+"`:status`"+`, age::text -- :status
+		/* :status */ FROM users WHERE status = $1 AND note = 'it''s :status'`, query)
+	assert.Equal(t, []any{"active"}, args)
+
+	query, args, err = Named("SELECT 'unterminated :status", searchParams{}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 'unterminated :status", query)
+	assert.Nil(t, args)
+
+	query, _, err = Named("SELECT 1 -- :status", searchParams{}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1 -- :status", query)
+
+	query, _, err = Named("SELECT :1, a : b /* :status", searchParams{}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT :1, a : b /* :status", query)
+}
+
+func TestNamed_shouldRaiseError(t *testing.T) {
+	_, _, err := Named("SELECT id FROM users WHERE name = :name", searchParams{}, Question)
+	assert.ErrorIs(t, err, ErrUnknownParameter)
+	assert.ErrorContains(t, err, "name")
+
+	type requiredParams struct {
+		ID   optional.Option[int64]  `db:"id,required"`
+		Name optional.Option[string] `db:"name"`
+	}
+	_, _, err = Named("SELECT id FROM users WHERE id = :id", requiredParams{}, Question)
+	assert.ErrorIs(t, err, ErrMissingParameter)
+
+	query, args, err := Named("SELECT id FROM users WHERE id = :id AND name = :name", requiredParams{ID: optional.Some[int64](1)}, Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = ? AND name = ?", query)
+	assert.Equal(t, []any{int64(1), nil}, args)
+
+	_, _, err = Named("SELECT 1", 1, Question)
+	assert.ErrorIs(t, err, ErrInvalidStruct)
+}
+
+func TestNamed_SQL(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := db.Exec(`INSERT INTO users(id, name, status, age) VALUES
+		(1, 'foo', 'active', 20),
+		(2, 'bar', 'inactive', 30),
+		(3, 'baz', 'active', NULL)`)
+	assert.NoError(t, err)
+
+	search := func(params searchParams) []int64 {
+		query, args, err := Named(`SELECT id FROM users
+			WHERE (:status IS NULL OR status = :status) AND (:min_age IS NULL OR age >= :min_age) AND id IN (:ids)
+			ORDER BY id LIMIT :limit`, params, Question)
+		assert.NoError(t, err)
+
+		rows, err := db.Query(query, args...)
+		assert.NoError(t, err)
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		var got []int64
+		for rows.Next() {
+			var id int64
+			assert.NoError(t, rows.Scan(&id))
+			got = append(got, id)
+		}
+		return got
+	}
+
+	assert.Equal(t, []int64{1, 3}, search(searchParams{Status: optional.Some("active"), IDs: optional.Some([]int64{1, 2, 3}), Limit: 10}))
+	assert.Equal(t, []int64{2}, search(searchParams{MinAge: optional.Some(25), IDs: optional.Some([]int64{1, 2, 3}), Limit: 10}))
+	assert.Equal(t, []int64{1}, search(searchParams{IDs: optional.Some([]int64{1, 2, 3}), Limit: 1}))
+	assert.Nil(t, search(searchParams{IDs: optional.Some([]int64{}), Limit: 10}))
+}
@@ -0,0 +1,68 @@
+// Package sqlbuilder provides the small SQL builders that are driven by optional.Option values.
+//
+// The builders produce the parameterized SQL and its arguments. Note that the column names are embedded into the SQL as they are,
+// so those must be trusted identifiers (i.e. must not come from the user input).
+package sqlbuilder
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+)
+
+// PlaceholderStyle represents the style of the bind parameter placeholders.
+type PlaceholderStyle int
+
+const (
+	// Question is the placeholder style of `?` (e.g. SQLite and MySQL).
+	Question PlaceholderStyle = iota
+	// Dollar is the placeholder style of `$1`, `$2`, ... (e.g. PostgreSQL).
+	Dollar
+)
+
+// builder accumulates the SQL text and the arguments.
+type builder struct {
+	sql         strings.Builder
+	args        []any
+	placeholder PlaceholderStyle
+}
+
+func newBuilder(placeholder PlaceholderStyle) *builder {
+	return &builder{
+		placeholder: placeholder,
+	}
+}
+
+func (b *builder) write(s string) {
+	b.sql.WriteString(s)
+}
+
+// bind appends the argument and writes the placeholder for that.
+func (b *builder) bind(arg any) {
+	b.args = append(b.args, arg)
+	if b.placeholder == Dollar {
+		b.sql.WriteByte('$')
+		b.sql.WriteString(strconv.Itoa(len(b.args)))
+		return
+	}
+	b.sql.WriteByte('?')
+}
+
+// bindValuer binds the value of the Option (i.e. driver.Valuer); see also driverValueOf.
+func (b *builder) bindValuer(valuer driver.Valuer) {
+	b.bind(driverValueOf(valuer))
+}
+
+func (b *builder) build() (string, []any) {
+	return b.sql.String(), b.args
+}
+
+// driverValueOf returns the result of Option#Value(), so that the builders bind the value in the same encoding as database/sql and Named.
+// If Option#Value() fails, this returns the Option as it is, and then database/sql reports the error on the execution.
+func driverValueOf(valuer driver.Valuer) any {
+	v, err := valuer.Value()
+	if err != nil {
+		return valuer
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package sqlbuilder
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+
+	"github.com/moznion/go-optional"
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+var (
+	// ErrInvalidStruct represents the error that is raised when the given value is not a struct or a non-nil pointer to a struct.
+	ErrInvalidStruct = errors.New("value must be a struct or a non-nil pointer to a struct")
+	// ErrNoFieldsToUpdate represents the error that is raised when the patch doesn't have any field to update.
+	ErrNoFieldsToUpdate = errors.New("no fields to update")
+	// ErrNoWhereCondition represents the error that is raised when the WHERE condition is missing or skipped; this prevents updating all the rows accidentally.
+	ErrNoWhereCondition = errors.New("no WHERE condition")
+)
+
+// SetNull returns the value that makes Update set the column to NULL, i.e. Some[None[T]].
+// This is for the `optional.Option[optional.Option[T]]` field of the patch struct.
+func SetNull[T any]() optional.Option[optional.Option[T]] {
+	return optional.Some(optional.None[T]())
+}
+
+// SetValue returns the value that makes Update set the column to the value, i.e. Some[Some[T]].
+// This is for the `optional.Option[optional.Option[T]]` field of the patch struct.
+func SetValue[T any](v T) optional.Option[optional.Option[T]] {
+	return optional.Some(optional.Some(v))
+}
+
+// Update builds the partial UPDATE statement (e.g. `UPDATE table SET a = ?, b = NULL WHERE id = ?`) and the arguments from the patch struct.
+//
+// The patch must be a struct (or a pointer to that) that has optional.Option fields with `db:"column_name"` struct tag, and only the Some fields are set:
+//   - optional.Option[T] field: Some[T] sets the column to the value, and None leaves the column unchanged
+//   - optional.Option[optional.Option[T]] field: Some[Some[T]] sets the column to the value, Some[None[T]] sets the column to NULL (see also SetNull()), and None leaves the column unchanged
+//
+// The fields that are not Option are ignored, and the fields of the embedded structs are also dealt with.
+// This returns ErrNoFieldsToUpdate if there is no field to set, and ErrNoWhereCondition if the where condition is nil or skipped.
+func Update(table string, patch any, where Condition, placeholder PlaceholderStyle) (string, []any, error) {
+	rv, ok := indirectStruct(patch)
+	if !ok {
+		return "", nil, ErrInvalidStruct
+	}
+	if where == nil || where.skipped() {
+		return "", nil, ErrNoWhereCondition
+	}
+
+	b := newBuilder(placeholder)
+	b.write("UPDATE ")
+	b.write(table)
+	b.write(" SET ")
+
+	set := 0
+	for _, field := range columnFields(rv) {
+		if !field.isOption || !optiontype.IsSome(field.value) {
+			continue
+		}
+
+		if set > 0 {
+			b.write(", ")
+		}
+		b.write(field.column)
+		b.write(" = ")
+		appendOptionValue(b, field.value)
+		set++
+	}
+	if set <= 0 {
+		return "", nil, ErrNoFieldsToUpdate
+	}
+
+	b.write(" ")
+	appendWhere(b, where)
+
+	query, args := b.build()
+	return query, args, nil
+}
+
+// appendOptionValue writes the value of the Some field, which is bound as the result of Option#Value(). If the field is the nested Option, the inner None is written as NULL.
+func appendOptionValue(b *builder, option reflect.Value) {
+	elem := optiontype.Elem(option)
+	if optiontype.Is(elem.Type()) {
+		if !optiontype.IsSome(elem) {
+			b.write("NULL")
+			return
+		}
+		b.bindValuer(elem.Interface().(driver.Valuer))
+		return
+	}
+	b.bindValuer(option.Interface().(driver.Valuer))
+}
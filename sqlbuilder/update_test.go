@@ -0,0 +1,118 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUserAudit struct {
+	UpdatedBy optional.Option[string] `db:"updated_by"`
+}
+
+type testUserPatch struct {
+	ID     int64                                 `db:"id"`
+	Name   optional.Option[string]               `db:"name"`
+	Status optional.Option[string]               `db:"status"`
+	Age    optional.Option[optional.Option[int]] `db:"age"`
+	Note   optional.Option[string]               `db:"-"`
+	testUserAudit
+}
+
+func TestUpdate(t *testing.T) {
+	patch := testUserPatch{
+		ID:            123,
+		Name:          optional.Some[string]("foo"),
+		Age:           SetNull[int](),
+		Note:          optional.Some[string]("ignored"),
+		testUserAudit: testUserAudit{UpdatedBy: optional.Some[string]("admin")},
+	}
+
+	query, args, err := Update("users", patch, Eq("id", optional.Some[int64](patch.ID)), Question)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, age = NULL, updated_by = ? WHERE id = ?", query)
+	assert.Equal(t, []any{"foo", "admin", int64(123)}, args)
+
+	patch = testUserPatch{
+		Status: optional.Some[string]("inactive"),
+		Age:    SetValue[int](42),
+	}
+	query, args, err = Update("users", &patch, And(Eq("id", optional.Some[int64](1)), Eq("status", optional.Some[string]("active"))), Dollar)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET status = $1, age = $2 WHERE id = $3 AND status = $4", query)
+	assert.Equal(t, []any{"inactive", int64(42), int64(1), "active"}, args)
+}
+
+func TestUpdate_errors(t *testing.T) {
+	where := Eq("id", optional.Some[int64](1))
+
+	_, _, err := Update("users", testUserPatch{}, where, Question)
+	assert.ErrorIs(t, err, ErrNoFieldsToUpdate)
+
+	patch := testUserPatch{Name: optional.Some[string]("foo")}
+	_, _, err = Update("users", patch, nil, Question)
+	assert.ErrorIs(t, err, ErrNoWhereCondition)
+	_, _, err = Update("users", patch, Eq("id", optional.None[int64]()), Question)
+	assert.ErrorIs(t, err, ErrNoWhereCondition)
+
+	_, _, err = Update("users", 1, where, Question)
+	assert.ErrorIs(t, err, ErrInvalidStruct)
+	_, _, err = Update("users", (*testUserPatch)(nil), where, Question)
+	assert.ErrorIs(t, err, ErrInvalidStruct)
+}
+
+func TestUpdate_SQL(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := db.Exec(`INSERT INTO users(id, name, status, age) VALUES (1, 'foo', 'active', 20), (2, 'bar', 'active', 30)`)
+	assert.NoError(t, err)
+
+	query, args, err := Update("users", testUserPatch{Status: optional.Some[string]("inactive"), Age: SetNull[int]()}, Eq("id", optional.Some[int](1)), Question)
+	assert.NoError(t, err)
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	var (
+		name   string
+		status string
+		age    optional.Option[int]
+	)
+	err = db.QueryRow("SELECT name, status, age FROM users WHERE id = 1").Scan(&name, &status, &age)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", name)
+	assert.Equal(t, "inactive", status)
+	assert.True(t, age.IsNone())
+
+	err = db.QueryRow("SELECT name, status, age FROM users WHERE id = 2").Scan(&name, &status, &age)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", name)
+	assert.Equal(t, "active", status)
+	assert.Equal(t, optional.Some[int](30), age)
+}
+
+func TestUpdate_SQL_textMarshaler(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := db.Exec(`INSERT INTO users(id, name) VALUES (1, 'foo')`)
+	assert.NoError(t, err)
+
+	type patch struct {
+		Name   optional.Option[testFullName]                  `db:"name"`
+		Status optional.Option[optional.Option[testFullName]] `db:"status"`
+	}
+	query, args, err := Update("users", patch{
+		Name:   optional.Some(testFullName{First: "foo", Last: "bar"}),
+		Status: SetValue(testFullName{First: "in", Last: "active"}),
+	}, Eq("id", optional.Some[int](1)), Question)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"foo bar", "in active", int64(1)}, args)
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	var name, status string
+	err = db.QueryRow("SELECT name, status FROM users WHERE id = 1").Scan(&name, &status)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo bar", name)
+	assert.Equal(t, "in active", status)
+}
@@ -0,0 +1,202 @@
+package sqlbuilder
+
+import (
+	"github.com/moznion/go-optional"
+)
+
+// Condition is a condition of WHERE clause. Each predicate takes an Option value, and that is skipped when the value is None.
+// The value is bound as the result of Option#Value(), in the same manner as Named.
+type Condition interface {
+	// skipped returns whether the condition is omitted from the SQL or not.
+	skipped() bool
+	// appendSQL writes the condition. If nested is true, the condition is a part of the outer group.
+	appendSQL(b *builder, nested bool)
+}
+
+type comparison struct {
+	column   string
+	operator string
+	value    any
+	present  bool
+}
+
+func newComparison[T any](column string, operator string, value optional.Option[T]) Condition {
+	return &comparison{
+		column:   column,
+		operator: operator,
+		value:    driverValueOf(value),
+		present:  value.IsSome(),
+	}
+}
+
+func (c *comparison) skipped() bool {
+	return !c.present
+}
+
+func (c *comparison) appendSQL(b *builder, _ bool) {
+	b.write(c.column)
+	b.write(" ")
+	b.write(c.operator)
+	b.write(" ")
+	b.bind(c.value)
+}
+
+// Eq makes the condition `column = value`. If the value is None, this condition is skipped.
+func Eq[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, "=", value)
+}
+
+// Ne makes the condition `column <> value`. If the value is None, this condition is skipped.
+func Ne[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, "<>", value)
+}
+
+// Gt makes the condition `column > value`. If the value is None, this condition is skipped.
+func Gt[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, ">", value)
+}
+
+// Gte makes the condition `column >= value`. If the value is None, this condition is skipped.
+func Gte[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, ">=", value)
+}
+
+// Lt makes the condition `column < value`. If the value is None, this condition is skipped.
+func Lt[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, "<", value)
+}
+
+// Lte makes the condition `column <= value`. If the value is None, this condition is skipped.
+func Lte[T any](column string, value optional.Option[T]) Condition {
+	return newComparison(column, "<=", value)
+}
+
+// Like makes the condition `column LIKE pattern`. The pattern is bound as it is, so it should contain the wildcards (e.g. `%foo%`) if necessary.
+// If the pattern is None, this condition is skipped.
+func Like(column string, pattern optional.Option[string]) Condition {
+	return newComparison(column, "LIKE", pattern)
+}
+
+type in struct {
+	column  string
+	values  []any
+	present bool
+}
+
+// In makes the condition `column IN (values...)`. If the values is None, this condition is skipped.
+// If the values is Some but empty, this makes the condition that never matches (i.e. `1 = 0`), because nothing is in the empty set.
+func In[T any](column string, values optional.Option[[]T]) Condition {
+	bound := make([]any, 0, len(values.Unwrap()))
+	for _, v := range values.Unwrap() {
+		bound = append(bound, driverValueOf(optional.Some(v)))
+	}
+	return &in{
+		column:  column,
+		values:  bound,
+		present: values.IsSome(),
+	}
+}
+
+func (c *in) skipped() bool {
+	return !c.present
+}
+
+func (c *in) appendSQL(b *builder, _ bool) {
+	if len(c.values) <= 0 {
+		b.write("1 = 0")
+		return
+	}
+
+	b.write(c.column)
+	b.write(" IN (")
+	for i, v := range c.values {
+		if i > 0 {
+			b.write(", ")
+		}
+		b.bind(v)
+	}
+	b.write(")")
+}
+
+type group struct {
+	operator   string
+	conditions []Condition
+}
+
+// And combines the conditions with AND. The skipped conditions are excluded, and if all the conditions are skipped, this group is also skipped.
+func And(conditions ...Condition) Condition {
+	return &group{
+		operator:   " AND ",
+		conditions: conditions,
+	}
+}
+
+// Or combines the conditions with OR. The skipped conditions are excluded, and if all the conditions are skipped, this group is also skipped.
+func Or(conditions ...Condition) Condition {
+	return &group{
+		operator:   " OR ",
+		conditions: conditions,
+	}
+}
+
+func (g *group) active() []Condition {
+	active := make([]Condition, 0, len(g.conditions))
+	for _, c := range g.conditions {
+		if c != nil && !c.skipped() {
+			active = append(active, c)
+		}
+	}
+	return active
+}
+
+func (g *group) skipped() bool {
+	return len(g.active()) <= 0
+}
+
+func (g *group) appendSQL(b *builder, nested bool) {
+	active := g.active()
+	parenthesize := nested && len(active) > 1
+	if parenthesize {
+		b.write("(")
+	}
+	for i, c := range active {
+		if i > 0 {
+			b.write(g.operator)
+		}
+		c.appendSQL(b, true)
+	}
+	if parenthesize {
+		b.write(")")
+	}
+}
+
+// Build renders the condition into the parameterized SQL expression and the arguments.
+// If the condition is skipped, this returns an empty string and nil.
+func Build(condition Condition, placeholder PlaceholderStyle) (string, []any) {
+	b := newBuilder(placeholder)
+	appendCondition(b, condition)
+	return b.build()
+}
+
+// Where renders the condition into the parameterized WHERE clause (i.e. `WHERE ...`) and the arguments.
+// If the condition is skipped, this returns an empty string and nil, so the result can be appended to the query as it is.
+func Where(condition Condition, placeholder PlaceholderStyle) (string, []any) {
+	b := newBuilder(placeholder)
+	appendWhere(b, condition)
+	return b.build()
+}
+
+func appendCondition(b *builder, condition Condition) {
+	if condition == nil || condition.skipped() {
+		return
+	}
+	condition.appendSQL(b, false)
+}
+
+func appendWhere(b *builder, condition Condition) {
+	if condition == nil || condition.skipped() {
+		return
+	}
+	b.write("WHERE ")
+	condition.appendSQL(b, false)
+}
@@ -0,0 +1,176 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	cond := And(
+		Eq("status", optional.Some[string]("active")),
+		Gte("age", optional.None[int]()),
+		In("id", optional.Some[[]int64]([]int64{1, 2, 3})),
+		Or(
+			Like("name", optional.Some[string]("%foo%")),
+			Like("nickname", optional.Some[string]("%foo%")),
+		),
+		Or(
+			Eq("deleted", optional.None[bool]()),
+		),
+	)
+
+	query, args := Build(cond, Question)
+	assert.Equal(t, "status = ? AND id IN (?, ?, ?) AND (name LIKE ? OR nickname LIKE ?)", query)
+	assert.Equal(t, []any{"active", int64(1), int64(2), int64(3), "%foo%", "%foo%"}, args)
+
+	query, args = Build(cond, Dollar)
+	assert.Equal(t, "status = $1 AND id IN ($2, $3, $4) AND (name LIKE $5 OR nickname LIKE $6)", query)
+	assert.Equal(t, []any{"active", int64(1), int64(2), int64(3), "%foo%", "%foo%"}, args)
+}
+
+func TestBuild_comparisons(t *testing.T) {
+	query, args := Build(And(
+		Eq("a", optional.Some[int](1)),
+		Ne("b", optional.Some[int](2)),
+		Gt("c", optional.Some[int](3)),
+		Gte("d", optional.Some[int](4)),
+		Lt("e", optional.Some[int](5)),
+		Lte("f", optional.Some[int](6)),
+	), Question)
+	assert.Equal(t, "a = ? AND b <> ? AND c > ? AND d >= ? AND e < ? AND f <= ?", query)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)}, args)
+}
+
+func TestBuild_groups(t *testing.T) {
+	// the single active condition in the nested group doesn't need parentheses
+	query, args := Build(Or(
+		And(Eq("a", optional.Some[int](1)), Eq("b", optional.None[int]())),
+		And(Eq("c", optional.Some[int](3)), Eq("d", optional.Some[int](4))),
+	), Dollar)
+	assert.Equal(t, "a = $1 OR (c = $2 AND d = $3)", query)
+	assert.Equal(t, []any{int64(1), int64(3), int64(4)}, args)
+
+	query, args = Build(And(Eq("a", optional.None[int]()), Or()), Question)
+	assert.Equal(t, "", query)
+	assert.Nil(t, args)
+}
+
+func TestBuild_emptyIn(t *testing.T) {
+	query, args := Build(And(
+		In("id", optional.Some[[]int]([]int{})),
+		In("code", optional.None[[]string]()),
+	), Question)
+	assert.Equal(t, "1 = 0", query)
+	assert.Nil(t, args)
+}
+
+func TestWhere(t *testing.T) {
+	query, args := Where(Eq("id", optional.Some[int](1)), Dollar)
+	assert.Equal(t, "WHERE id = $1", query)
+	assert.Equal(t, []any{int64(1)}, args)
+
+	query, args = Where(Eq("id", optional.None[int]()), Dollar)
+	assert.Equal(t, "", query)
+	assert.Nil(t, args)
+
+	query, args = Where(nil, Dollar)
+	assert.Equal(t, "", query)
+	assert.Nil(t, args)
+}
+
+// testFullName is the value that is bound through encoding.TextMarshaler by Option#Value().
+type testFullName struct {
+	First string
+	Last  string
+}
+
+func (n testFullName) MarshalText() ([]byte, error) {
+	return []byte(n.First + " " + n.Last), nil
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	tmpfile, err := os.CreateTemp(os.TempDir(), "testdb")
+	assert.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	_, err = db.Exec(`CREATE TABLE users (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'active',
+		age INTEGER
+	);`)
+	assert.NoError(t, err)
+
+	return db
+}
+
+func TestWhere_SQL(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := db.Exec(`INSERT INTO users(id, name, status, age) VALUES
+		(1, 'foo', 'active', 20),
+		(2, 'bar', 'inactive', 30),
+		(3, 'baz', 'active', NULL)`)
+	assert.NoError(t, err)
+
+	search := func(status optional.Option[string], minAge optional.Option[int], ids optional.Option[[]int], name optional.Option[string]) []int {
+		where, args := Where(And(
+			Eq("status", status),
+			Gte("age", minAge),
+			In("id", ids),
+			Like("name", name),
+		), Question)
+
+		rows, err := db.Query("SELECT id FROM users "+where+" ORDER BY id", args...)
+		assert.NoError(t, err)
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		var got []int
+		for rows.Next() {
+			var id int
+			assert.NoError(t, rows.Scan(&id))
+			got = append(got, id)
+		}
+		return got
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, search(optional.None[string](), optional.None[int](), optional.None[[]int](), optional.None[string]()))
+	assert.Equal(t, []int{1, 3}, search(optional.Some[string]("active"), optional.None[int](), optional.None[[]int](), optional.None[string]()))
+	assert.Equal(t, []int{2}, search(optional.None[string](), optional.Some[int](25), optional.None[[]int](), optional.None[string]()))
+	assert.Equal(t, []int{2, 3}, search(optional.None[string](), optional.None[int](), optional.Some[[]int]([]int{2, 3}), optional.Some[string]("ba%")))
+	assert.Nil(t, search(optional.None[string](), optional.None[int](), optional.Some[[]int]([]int{}), optional.None[string]()))
+}
+
+func TestWhere_SQL_textMarshaler(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := db.Exec(`INSERT INTO users(id, name) VALUES (1, 'foo bar'), (2, 'baz qux')`)
+	assert.NoError(t, err)
+
+	where, args := Where(Eq("name", optional.Some(testFullName{First: "baz", Last: "qux"})), Question)
+	assert.Equal(t, []any{"baz qux"}, args)
+	var id int
+	err = db.QueryRow("SELECT id FROM users "+where, args...).Scan(&id)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	where, args = Where(In("name", optional.Some([]testFullName{{First: "foo", Last: "bar"}})), Question)
+	assert.Equal(t, []any{"foo bar"}, args)
+	err = db.QueryRow("SELECT id FROM users "+where, args...).Scan(&id)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
@@ -0,0 +1,80 @@
+package sqlrow
+
+import (
+	"fmt"
+
+	"github.com/moznion/go-optional"
+)
+
+// ScanColumn scans the single column of all the rows into a slice of Option[T], and closes the rows.
+// Each cell is scanned by Option#Scan(), so the NULL value becomes None. If there is no row, this returns an empty slice.
+func ScanColumn[T any](rows Rows) ([]optional.Option[T], error) {
+	return ScanColumnWithSizeHint[T](rows, 0)
+}
+
+// ScanColumnWithSizeHint is the same as ScanColumn, but this preallocates the slice with the capacity of sizeHint (i.e. the expected number of the rows).
+func ScanColumnWithSizeHint[T any](rows Rows, sizeHint int) ([]optional.Option[T], error) {
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) != 1 {
+		return nil, fmt.Errorf("ScanColumn requires exactly one column, but got %d columns", len(columns))
+	}
+
+	result := make([]optional.Option[T], 0, max(sizeHint, 0))
+	for rows.Next() {
+		result = append(result, nil)
+		err := rows.Scan(&result[len(result)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScanColumns scans all the rows column by column, and closes the rows.
+// This returns a slice of Option[T] per column in the order of the columns, i.e. result[i][j] is the i-th column of the j-th row.
+// Each cell is scanned by Option#Scan(), so the NULL value becomes None.
+func ScanColumns[T any](rows Rows) ([][]optional.Option[T], error) {
+	return ScanColumnsWithSizeHint[T](rows, 0)
+}
+
+// ScanColumnsWithSizeHint is the same as ScanColumns, but this preallocates the slice of each column with the capacity of sizeHint (i.e. the expected number of the rows).
+func ScanColumnsWithSizeHint[T any](rows Rows, sizeHint int) ([][]optional.Option[T], error) {
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]optional.Option[T], len(columns))
+	for i := range result {
+		result[i] = make([]optional.Option[T], 0, max(sizeHint, 0))
+	}
+	targets := make([]any, len(columns))
+	for rows.Next() {
+		for i := range result {
+			result[i] = append(result[i], nil)
+			targets[i] = &result[i][len(result[i])-1]
+		}
+		err := rows.Scan(targets...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
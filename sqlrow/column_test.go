@@ -0,0 +1,199 @@
+package sqlrow
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+// openMetricsTestDB opens the database that has the metrics table with n rows; every third row has NULL values.
+func openMetricsTestDB(tb testing.TB, n int) *sql.DB {
+	tb.Helper()
+
+	db := openDB(tb, `CREATE TABLE metrics (
+		id INTEGER NOT NULL PRIMARY KEY,
+		value REAL,
+		weight REAL
+	);`)
+
+	_, err := db.Exec(`WITH RECURSIVE seq(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM seq WHERE i < ?)
+		INSERT INTO metrics(id, value, weight)
+		SELECT i, CASE WHEN i % 3 = 0 THEN NULL ELSE i * 0.5 END, CASE WHEN i % 3 = 0 THEN NULL ELSE i * 2.0 END FROM seq`, n)
+	assert.NoError(tb, err)
+
+	return db
+}
+
+func TestScanColumn(t *testing.T) {
+	db := openMetricsTestDB(t, 4)
+
+	rows, err := db.Query("SELECT value FROM metrics ORDER BY id")
+	assert.NoError(t, err)
+	values, err := ScanColumn[float64](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []optional.Option[float64]{
+		optional.Some(0.5),
+		optional.Some(1.0),
+		optional.None[float64](),
+		optional.Some(2.0),
+	}, values)
+
+	rows, err = db.Query("SELECT value FROM metrics ORDER BY id")
+	assert.NoError(t, err)
+	values, err = ScanColumnWithSizeHint[float64](rows, 100)
+	assert.NoError(t, err)
+	assert.Len(t, values, 4)
+	assert.Equal(t, 100, cap(values))
+
+	rows, err = db.Query("SELECT value FROM metrics WHERE id < 0")
+	assert.NoError(t, err)
+	values, err = ScanColumn[float64](rows)
+	assert.NoError(t, err)
+	assert.NotNil(t, values)
+	assert.Len(t, values, 0)
+}
+
+func TestScanColumn_shouldRaiseError(t *testing.T) {
+	db := openMetricsTestDB(t, 4)
+
+	rows, err := db.Query("SELECT id, value FROM metrics")
+	assert.NoError(t, err)
+	_, err = ScanColumn[float64](rows)
+	assert.ErrorContains(t, err, "requires exactly one column, but got 2 columns")
+
+	rows, err = db.Query("SELECT 'x' FROM metrics")
+	assert.NoError(t, err)
+	_, err = ScanColumn[float64](rows)
+	assert.Error(t, err)
+}
+
+func TestScanColumns(t *testing.T) {
+	db := openMetricsTestDB(t, 3)
+
+	rows, err := db.Query("SELECT value, weight FROM metrics ORDER BY id")
+	assert.NoError(t, err)
+	columns, err := ScanColumns[float64](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]optional.Option[float64]{
+		{optional.Some(0.5), optional.Some(1.0), optional.None[float64]()},
+		{optional.Some(2.0), optional.Some(4.0), optional.None[float64]()},
+	}, columns)
+
+	rows, err = db.Query("SELECT value, weight FROM metrics WHERE id < 0")
+	assert.NoError(t, err)
+	columns, err = ScanColumnsWithSizeHint[float64](rows, 10)
+	assert.NoError(t, err)
+	assert.Len(t, columns, 2)
+	for _, column := range columns {
+		assert.Len(t, column, 0)
+		assert.Equal(t, 10, cap(column))
+	}
+
+	rows, err = db.Query("SELECT value, 'x' FROM metrics")
+	assert.NoError(t, err)
+	_, err = ScanColumns[float64](rows)
+	assert.Error(t, err)
+}
+
+const benchmarkMetricsRows = 10000
+
+func BenchmarkScanColumn(b *testing.B) {
+	db := openMetricsTestDB(b, benchmarkMetricsRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query("SELECT value FROM metrics")
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = ScanColumn[float64](rows)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanColumnWithSizeHint(b *testing.B) {
+	db := openMetricsTestDB(b, benchmarkMetricsRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query("SELECT value FROM metrics")
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = ScanColumnWithSizeHint[float64](rows, benchmarkMetricsRows)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkScanColumn_manualLoop is the baseline that scans the column by the hand-written loop with sql.NullFloat64.
+func BenchmarkScanColumn_manualLoop(b *testing.B) {
+	db := openMetricsTestDB(b, benchmarkMetricsRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query("SELECT value FROM metrics")
+		if err != nil {
+			b.Fatal(err)
+		}
+		var values []optional.Option[float64]
+		for rows.Next() {
+			var v sql.NullFloat64
+			if err := rows.Scan(&v); err != nil {
+				b.Fatal(err)
+			}
+			values = append(values, optional.FromSQLNullFloat64(v))
+		}
+		if err := rows.Err(); err != nil {
+			b.Fatal(err)
+		}
+		_ = rows.Close()
+	}
+}
+
+func BenchmarkScanColumns(b *testing.B) {
+	db := openMetricsTestDB(b, benchmarkMetricsRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query("SELECT value, weight FROM metrics")
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = ScanColumnsWithSizeHint[float64](rows, benchmarkMetricsRows)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkScanColumns_manualLoop is the baseline that scans the columns by the hand-written loop with sql.NullFloat64.
+func BenchmarkScanColumns_manualLoop(b *testing.B) {
+	db := openMetricsTestDB(b, benchmarkMetricsRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query("SELECT value, weight FROM metrics")
+		if err != nil {
+			b.Fatal(err)
+		}
+		var values, weights []optional.Option[float64]
+		for rows.Next() {
+			var v, w sql.NullFloat64
+			if err := rows.Scan(&v, &w); err != nil {
+				b.Fatal(err)
+			}
+			values = append(values, optional.FromSQLNullFloat64(v))
+			weights = append(weights, optional.FromSQLNullFloat64(w))
+		}
+		if err := rows.Err(); err != nil {
+			b.Fatal(err)
+		}
+		_ = rows.Close()
+	}
+}
@@ -0,0 +1,133 @@
+package sqlrow
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/moznion/go-optional/internal/optiontype"
+)
+
+// columnGroup is the group of the columns that are scanned into an optional.Option[T] field of the struct type T.
+type columnGroup struct {
+	name   string
+	option reflect.Value
+	fields map[string]structField
+	strict bool
+
+	positions []int
+	columns   []string
+	detectors []*nullDetector
+}
+
+// groupOf returns the column group that the column (e.g. `child.id`) belongs to. The new group is appended to the groups if that is not there yet.
+func groupOf(structValue reflect.Value, fields map[string]structField, column string, groups *[]*columnGroup) (*columnGroup, error) {
+	name, member, found := strings.Cut(column, ".")
+	if !found {
+		return nil, fmt.Errorf("missing destination field for column %q in %s", column, structValue.Type())
+	}
+	for _, g := range *groups {
+		if g.name == name {
+			return g, g.validate(column, member)
+		}
+	}
+
+	f, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("missing destination field for column %q in %s", column, structValue.Type())
+	}
+	option := fieldByIndexAlloc(structValue, f.index)
+	if !optiontype.Is(option.Type()) || option.Type().Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("destination field of column group %q in %s must be optional.Option of a struct, but %s", name, structValue.Type(), option.Type())
+	}
+
+	g := &columnGroup{
+		name:   name,
+		option: option,
+		fields: fieldsOf(option.Type().Elem()),
+		strict: f.hasOption("strict"),
+	}
+	*groups = append(*groups, g)
+	return g, g.validate(column, member)
+}
+
+func (g *columnGroup) validate(column string, member string) error {
+	if _, ok := g.fields[member]; !ok {
+		return fmt.Errorf("missing destination field for column %q in %s", column, g.option.Type().Elem())
+	}
+	return nil
+}
+
+// add registers the column at the position of the row, and returns the scan destination that detects NULL.
+func (g *columnGroup) add(position int, column string) any {
+	detector := &nullDetector{}
+	g.positions = append(g.positions, position)
+	g.columns = append(g.columns, column)
+	g.detectors = append(g.detectors, detector)
+	return detector
+}
+
+// nulls returns the number of the NULL columns in the group.
+func (g *columnGroup) nulls() int {
+	n := 0
+	for _, d := range g.detectors {
+		if d.null {
+			n++
+		}
+	}
+	return n
+}
+
+// nullDetector is a sql.Scanner that only records whether the value is NULL or not.
+type nullDetector struct {
+	null bool
+}
+
+func (d *nullDetector) Scan(src any) error {
+	d.null = src == nil
+	return nil
+}
+
+// discard is a sql.Scanner that ignores the value.
+type discard struct{}
+
+func (discard) Scan(any) error {
+	return nil
+}
+
+// scanGroups sets the Option fields of the column groups after the columns are scanned once.
+// If all the columns of a group are NULL, the field becomes None. Otherwise, the current row is scanned again to fill the struct of Some,
+// so that the columns are converted with the standard rules of database/sql (the NULL value into a non-Option field is an error).
+func scanGroups(rows Rows, columns []string, groups []*columnGroup) error {
+	var targets []any
+	for _, g := range groups {
+		nulls := g.nulls()
+		if nulls == len(g.detectors) {
+			g.option.Set(reflect.Zero(g.option.Type()))
+			continue
+		}
+		if nulls > 0 && g.strict {
+			return fmt.Errorf("column group %q is partially NULL (%d of %d columns)", g.name, nulls, len(g.detectors))
+		}
+
+		if targets == nil {
+			targets = make([]any, len(columns))
+			for i := range targets {
+				targets[i] = discard{}
+			}
+		}
+		some := optiontype.Some(g.option.Type())
+		elem := optiontype.Elem(some)
+		for i, position := range g.positions {
+			_, member, _ := strings.Cut(g.columns[i], ".")
+			field := fieldByIndexAlloc(elem, g.fields[member].index)
+			targets[position] = scanTargetOf(field, g.columns[i])
+		}
+		g.option.Set(some)
+	}
+
+	if targets == nil {
+		return nil
+	}
+	return rows.Scan(targets...)
+}
@@ -0,0 +1,171 @@
+package sqlrow
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func openJoinTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	return openDB(t, `CREATE TABLE parents (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name TEXT NOT NULL
+	);
+	CREATE TABLE children (
+		id INTEGER PRIMARY KEY,
+		parent_id INTEGER NOT NULL,
+		name TEXT,
+		note TEXT
+	);
+	INSERT INTO parents(id, name) VALUES (1, 'alice'), (2, 'bob'), (3, 'carol');
+	INSERT INTO children(id, parent_id, name, note) VALUES (10, 1, 'foo', 'n'), (30, 3, NULL, NULL);`)
+}
+
+type testChild struct {
+	ID   int64                   `db:"id"`
+	Name optional.Option[string] `db:"name"`
+	Note optional.Option[string] `db:"note"`
+}
+
+type testParent struct {
+	ID    int64                      `db:"id"`
+	Name  string                     `db:"name"`
+	Child optional.Option[testChild] `db:"child"`
+}
+
+const joinQuery = `SELECT p.id, p.name, c.id AS "child.id", c.name AS "child.name", c.note AS "child.note"
+	FROM parents p LEFT JOIN children c ON c.parent_id = p.id ORDER BY p.id`
+
+func TestScanAll_columnGroup(t *testing.T) {
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(joinQuery)
+	assert.NoError(t, err)
+
+	parents, err := ScanAll[testParent](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []testParent{
+		{
+			ID:   1,
+			Name: "alice",
+			Child: optional.Some(testChild{
+				ID:   10,
+				Name: optional.Some("foo"),
+				Note: optional.Some("n"),
+			}),
+		},
+		{
+			ID:    2,
+			Name:  "bob",
+			Child: optional.None[testChild](),
+		},
+		{
+			ID:   3,
+			Name: "carol",
+			Child: optional.Some(testChild{
+				ID:   30,
+				Name: optional.None[string](),
+				Note: optional.None[string](),
+			}),
+		},
+	}, parents)
+}
+
+func TestScanAll_columnGroupInterleaved(t *testing.T) {
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(`SELECT c.name AS "child.name", p.id, c.id AS "child.id", p.name
+		FROM parents p LEFT JOIN children c ON c.parent_id = p.id WHERE p.id IN (1, 2) ORDER BY p.id`)
+	assert.NoError(t, err)
+
+	parents, err := ScanAll[testParent](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []testParent{
+		{ID: 1, Name: "alice", Child: optional.Some(testChild{ID: 10, Name: optional.Some("foo")})},
+		{ID: 2, Name: "bob", Child: optional.None[testChild]()},
+	}, parents)
+}
+
+func TestScanAll_columnGroupStrict(t *testing.T) {
+	type strictParent struct {
+		ID    int64                      `db:"id"`
+		Name  string                     `db:"name"`
+		Child optional.Option[testChild] `db:"child,strict"`
+	}
+
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(joinQuery + " LIMIT 2")
+	assert.NoError(t, err)
+	parents, err := ScanAll[strictParent](rows)
+	assert.NoError(t, err)
+	assert.Len(t, parents, 2)
+	assert.True(t, parents[0].Child.IsSome())
+	assert.True(t, parents[1].Child.IsNone())
+
+	rows, err = db.Query(joinQuery)
+	assert.NoError(t, err)
+	_, err = ScanAll[strictParent](rows)
+	assert.ErrorContains(t, err, `column group "child" is partially NULL (2 of 3 columns)`)
+}
+
+func TestScanAll_columnGroupShouldRaiseErrorForNullIntoNonOptionField(t *testing.T) {
+	type requiredChild struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	type parent struct {
+		ID    int64                          `db:"id"`
+		Child optional.Option[requiredChild] `db:"child"`
+	}
+
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(`SELECT p.id, c.id AS "child.id", c.name AS "child.name"
+		FROM parents p LEFT JOIN children c ON c.parent_id = p.id ORDER BY p.id`)
+	assert.NoError(t, err)
+	_, err = ScanAll[parent](rows)
+	assert.Error(t, err)
+}
+
+func TestScan_columnGroup(t *testing.T) {
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(`SELECT p.id, p.name, c.id AS "child.id", c.name AS "child.name", c.note AS "child.note"
+		FROM parents p LEFT JOIN children c ON c.parent_id = p.id WHERE p.id = 2`)
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = rows.Close()
+	}()
+	assert.True(t, rows.Next())
+
+	// the stale value must be overwritten by None
+	parent := testParent{Child: optional.Some(testChild{ID: 99})}
+	err = Scan(rows, &parent)
+	assert.NoError(t, err)
+	assert.Equal(t, testParent{ID: 2, Name: "bob", Child: optional.None[testChild]()}, parent)
+}
+
+func TestScanAll_columnGroupShouldRaiseErrorForInvalidColumns(t *testing.T) {
+	db := openJoinTestDB(t)
+
+	rows, err := db.Query(`SELECT p.id, p.name, c.id AS "child.unknown" FROM parents p LEFT JOIN children c ON c.parent_id = p.id`)
+	assert.NoError(t, err)
+	_, err = ScanAll[testParent](rows)
+	assert.ErrorContains(t, err, `missing destination field for column "child.unknown"`)
+
+	rows, err = db.Query(`SELECT p.id, p.name AS "name.value" FROM parents p`)
+	assert.NoError(t, err)
+	_, err = ScanAll[testParent](rows)
+	assert.ErrorContains(t, err, "must be optional.Option of a struct")
+
+	rows, err = db.Query(`SELECT p.id, p.name AS "unknown.value" FROM parents p`)
+	assert.NoError(t, err)
+	_, err = ScanAll[testParent](rows)
+	assert.ErrorContains(t, err, `missing destination field for column "unknown.value"`)
+}
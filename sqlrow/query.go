@@ -0,0 +1,93 @@
+package sqlrow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/moznion/go-optional"
+)
+
+// Querier is an interface to run a query. *sql.DB, *sql.Tx and *sql.Conn satisfy this interface.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+var (
+	// ErrMultipleRows represents the error that is raised when the query returns multiple rows in strict mode.
+	ErrMultipleRows = errors.New("query returned multiple rows")
+)
+
+// QueryOne runs the query and scans the first row into T. The remaining rows are discarded.
+// If there is no row, this returns None instead of sql.ErrNoRows.
+//
+// If T is a struct (except for database/sql.Scanner implementations and time.Time), the columns are mapped to the fields in the same manner as ScanOne.
+// Otherwise, T is dealt with as a scalar value, so the query must return exactly one column.
+func QueryOne[T any](ctx context.Context, q Querier, query string, args ...any) (optional.Option[T], error) {
+	return queryOne[T](ctx, q, query, args, false)
+}
+
+// QueryOneStrict is the same as QueryOne, but this returns ErrMultipleRows if the query returns multiple rows.
+func QueryOneStrict[T any](ctx context.Context, q Querier, query string, args ...any) (optional.Option[T], error) {
+	return queryOne[T](ctx, q, query, args, true)
+}
+
+func queryOne[T any](ctx context.Context, q Querier, query string, args []any, strict bool) (optional.Option[T], error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return optional.None[T](), nil
+	}
+
+	var v T
+	err = scanValue(rows, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	if strict && rows.Next() {
+		return nil, ErrMultipleRows
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return optional.Some(v), nil
+}
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// scanValue scans the current row into the value that is pointed by dest. The struct is scanned by the columns mapping, and the other types are scanned as a scalar value.
+func scanValue(rows Rows, dest any) error {
+	rv := reflect.ValueOf(dest).Elem()
+	if isStructDestination(rv.Type()) {
+		return Scan(rows, dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(columns) != 1 {
+		return fmt.Errorf("scalar destination %s requires exactly one column, but got %d columns", rv.Type(), len(columns))
+	}
+	return rows.Scan(scanTargetOf(rv, columns[0]))
+}
+
+func isStructDestination(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ != timeType && !reflect.PointerTo(typ).Implements(scannerType)
+}
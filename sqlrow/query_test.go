@@ -0,0 +1,120 @@
+package sqlrow
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryOne_struct(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	user, err := QueryOne[testUser](ctx, db, "SELECT id, name, nickname, age, avatar, created_at FROM users WHERE id = ?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some(testUser{
+		ID:             2,
+		Name:           "bar",
+		Nickname:       optional.None[string](),
+		Age:            optional.None[int](),
+		Avatar:         optional.None[[]byte](),
+		testTimestamps: testTimestamps{CreatedAt: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+	}), user)
+
+	user, err = QueryOne[testUser](ctx, db, "SELECT id, name FROM users WHERE id = ?", 100)
+	assert.NoError(t, err)
+	assert.True(t, user.IsNone())
+}
+
+func TestQueryOne_scalar(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	name, err := QueryOne[string](ctx, db, "SELECT name FROM users WHERE id = ?", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some("foo"), name)
+
+	name, err = QueryOne[string](ctx, db, "SELECT name FROM users WHERE id = ?", 100)
+	assert.NoError(t, err)
+	assert.True(t, name.IsNone())
+
+	// the nullable column: the outer Option is the existence of the row, and the inner one is the nullability of the column
+	nickname, err := QueryOne[optional.Option[string]](ctx, db, "SELECT nickname FROM users WHERE id = ?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some(optional.None[string]()), nickname)
+
+	createdAt, err := QueryOne[time.Time](ctx, db, "SELECT created_at FROM users WHERE id = ?", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)), createdAt)
+
+	nullString, err := QueryOne[sql.NullString](ctx, db, "SELECT nickname FROM users WHERE id = ?", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some(sql.NullString{String: "f", Valid: true}), nullString)
+}
+
+func TestQueryOne_shouldRaiseErrorForScalar(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	_, err := QueryOne[string](ctx, db, "SELECT id, name FROM users WHERE id = ?", 1)
+	assert.ErrorContains(t, err, "requires exactly one column, but got 2 columns")
+
+	_, err = QueryOne[int](ctx, db, "SELECT age FROM users WHERE id = ?", 2)
+	assert.Error(t, err)
+
+	_, err = QueryOne[[]byte](ctx, db, "SELECT avatar FROM users WHERE id = ?", 2)
+	assert.ErrorContains(t, err, `column "avatar" is NULL`)
+
+	_, err = QueryOne[string](ctx, db, "SELECT name FROM unknown_table")
+	assert.Error(t, err)
+}
+
+func TestQueryOne_multipleRows(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	id, err := QueryOne[int64](ctx, db, "SELECT id FROM users ORDER BY id")
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[int64](1), id)
+
+	_, err = QueryOneStrict[int64](ctx, db, "SELECT id FROM users ORDER BY id")
+	assert.ErrorIs(t, err, ErrMultipleRows)
+
+	id, err = QueryOneStrict[int64](ctx, db, "SELECT id FROM users WHERE id = ?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some[int64](2), id)
+
+	id, err = QueryOneStrict[int64](ctx, db, "SELECT id FROM users WHERE id = ?", 100)
+	assert.NoError(t, err)
+	assert.True(t, id.IsNone())
+}
+
+func TestQueryOne_txAndConn(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	_, err = tx.ExecContext(ctx, "UPDATE users SET nickname = 'b' WHERE id = 2")
+	assert.NoError(t, err)
+	nickname, err := QueryOne[string](ctx, tx, "SELECT nickname FROM users WHERE id = ?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some("b"), nickname)
+	assert.NoError(t, tx.Rollback())
+
+	conn, err := db.Conn(ctx)
+	assert.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	name, err := QueryOne[string](ctx, conn, "SELECT name FROM users WHERE id = ?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, optional.Some("bar"), name)
+}
@@ -0,0 +1,258 @@
+// Package sqlrow provides the functions to scan database/sql rows into structs that have optional.Option fields.
+//
+// The columns are mapped to the struct fields by `db:"column_name"` struct tag. The nullable columns should be mapped to optional.Option[T] fields;
+// the NULL value is scanned as None for those fields, and scanning the NULL value into a non-Option field is an error
+// (except for the types that deal with NULL by themselves, i.e. pointer types and database/sql.Scanner implementations such as sql.NullString).
+// The fields of the embedded structs are also mapped.
+//
+// The group of the columns can be scanned into an optional.Option[T] field of the struct type T, e.g. the columns of the LEFT JOINed table.
+// The columns of the group are named as `<column of the Option field>.<column of T>` (e.g. `SELECT c.id AS "child.id" ...` for `db:"child"`).
+// If all the columns of the group are NULL, the field becomes None; otherwise that becomes Some with the scanned struct.
+// The partially NULL group is reported as an error if the field has `strict` tag option (e.g. `db:"child,strict"`).
+package sqlrow
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Rows is an interface of the result set of a query. *sql.Rows satisfies this interface.
+type Rows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+var (
+	// ErrInvalidDestination represents the error that is raised when the destination is not a non-nil pointer to a struct.
+	ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+)
+
+// ScanAll scans all the rows into a slice of the struct T, and closes the rows.
+// If there is no row, this returns an empty slice.
+func ScanAll[T any](rows Rows) ([]T, error) {
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0)
+	for rows.Next() {
+		var v T
+		err := scanStruct(rows, columns, &v)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScanOne scans the first row into the struct T, and closes the rows. The remaining rows are discarded.
+// If there is no row, this returns sql.ErrNoRows.
+func ScanOne[T any](rows Rows) (T, error) {
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var v T
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return v, err
+		}
+		return v, sql.ErrNoRows
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return v, err
+	}
+	err = scanStruct(rows, columns, &v)
+	if err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Scan scans the current row into the struct that is pointed by dest.
+// This is the building block of ScanAll and ScanOne; the caller must call rows.Next() before this, and this doesn't close the rows.
+func Scan(rows Rows, dest any) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	return scanStruct(rows, columns, dest)
+}
+
+func scanStruct(rows Rows, columns []string, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	targets, groups, err := scanTargets(rv.Elem(), columns)
+	if err != nil {
+		return err
+	}
+	err = rows.Scan(targets...)
+	if err != nil {
+		return err
+	}
+	return scanGroups(rows, columns, groups)
+}
+
+// scanTargets returns the scan destinations of the fields of the given addressable struct value, in the order of the columns, and the column groups.
+// The columns of the groups are scanned only to detect NULL at first, so scanGroups must be called after scanning the targets.
+// If there is a column that doesn't have the corresponding field, this returns an error.
+func scanTargets(structValue reflect.Value, columns []string) ([]any, []*columnGroup, error) {
+	fields := fieldsOf(structValue.Type())
+	targets := make([]any, len(columns))
+	var groups []*columnGroup
+	for i, column := range columns {
+		if f, ok := fields[column]; ok {
+			field := fieldByIndexAlloc(structValue, f.index)
+			targets[i] = scanTargetOf(field, column)
+			continue
+		}
+
+		g, err := groupOf(structValue, fields, column, &groups)
+		if err != nil {
+			return nil, nil, err
+		}
+		targets[i] = g.add(i, column)
+	}
+	return targets, groups, nil
+}
+
+var (
+	bytesType = reflect.TypeOf([]byte(nil))
+	anyType   = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+// scanTargetOf returns the scan destination of the field.
+// The types of []byte and any are wrapped to reject the NULL value because database/sql silently accepts NULL for them.
+func scanTargetOf(field reflect.Value, column string) any {
+	switch field.Type() {
+	case bytesType:
+		return &notNullScanner[[]byte]{field: field, column: column}
+	case anyType:
+		return &notNullScanner[any]{field: field, column: column}
+	}
+	return field.Addr().Interface()
+}
+
+// notNullScanner is a sql.Scanner that raises an error for the NULL value, and otherwise assigns the value with the standard rules of database/sql.
+type notNullScanner[T any] struct {
+	field  reflect.Value
+	column string
+}
+
+func (s *notNullScanner[T]) Scan(src any) error {
+	var v sql.Null[T]
+	err := v.Scan(src)
+	if err != nil {
+		return err
+	}
+	if !v.Valid {
+		return fmt.Errorf("column %q is NULL but the destination type %s is not optional.Option", s.column, s.field.Type())
+	}
+	s.field.Set(reflect.ValueOf(&v.V).Elem())
+	return nil
+}
+
+// fieldByIndexAlloc returns the nested field by the index, with allocating the nil pointers of the embedded structs.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// structField is the field that is mapped to the column.
+type structField struct {
+	index []int
+	// options are the tag options that follow the column name, e.g. `strict` of `db:"child,strict"`.
+	options string
+}
+
+func (f structField) hasOption(option string) bool {
+	for _, o := range strings.Split(f.options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+var fieldsCache sync.Map // map[reflect.Type]map[string]structField
+
+// fieldsOf returns the mapping of the column name to the field of the struct type.
+// The fields of the outer struct have priority over the fields of the embedded structs.
+func fieldsOf(typ reflect.Type) map[string]structField {
+	if cached, ok := fieldsCache.Load(typ); ok {
+		return cached.(map[string]structField)
+	}
+
+	fields := make(map[string]structField)
+	collectFields(typ, nil, fields)
+	fieldsCache.Store(typ, fields)
+	return fields
+}
+
+func collectFields(typ reflect.Type, parentIndex []int, fields map[string]structField) {
+	var embedded []reflect.StructField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, hasTag := field.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int{}, parentIndex...), i)
+		if hasTag && field.IsExported() {
+			name, options, _ := strings.Cut(tag, ",")
+			if _, exists := fields[name]; !exists {
+				fields[name] = structField{index: index, options: options}
+			}
+			continue
+		}
+
+		if field.Anonymous {
+			field.Index = index
+			embedded = append(embedded, field)
+		}
+	}
+
+	for _, field := range embedded {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			if !field.IsExported() {
+				// the nil pointer of the unexported embedded struct can't be allocated via reflection
+				continue
+			}
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			collectFields(fieldType, field.Index, fields)
+		}
+	}
+}
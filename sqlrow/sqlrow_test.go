@@ -0,0 +1,207 @@
+package sqlrow
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/assert"
+)
+
+// openDB opens the SQLite database in the temporary directory of the test, and executes the schema (and the seed data) on that.
+func openDB(tb testing.TB, schema string) *sql.DB {
+	tb.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(tb.TempDir(), "test.db"))
+	assert.NoError(tb, err)
+	tb.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	_, err = db.Exec(schema)
+	assert.NoError(tb, err)
+
+	return db
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	return openDB(t, `CREATE TABLE users (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name TEXT NOT NULL,
+		nickname TEXT,
+		age INTEGER,
+		avatar BLOB,
+		created_at DATETIME NOT NULL
+	);
+	INSERT INTO users(id, name, nickname, age, avatar, created_at) VALUES
+		(1, 'foo', 'f', 20, x'01', '2006-01-02 15:04:05'),
+		(2, 'bar', NULL, NULL, NULL, '2006-01-02 15:04:05');`)
+}
+
+type testTimestamps struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type testUser struct {
+	ID       int64                   `db:"id"`
+	Name     string                  `db:"name"`
+	Nickname optional.Option[string] `db:"nickname"`
+	Age      optional.Option[int]    `db:"age"`
+	Avatar   optional.Option[[]byte] `db:"avatar"`
+	Ignored  string                  `db:"-"`
+	testTimestamps
+}
+
+func TestScanAll(t *testing.T) {
+	db := openTestDB(t)
+
+	rows, err := db.Query("SELECT id, name, nickname, age, avatar, created_at FROM users ORDER BY id")
+	assert.NoError(t, err)
+
+	users, err := ScanAll[testUser](rows)
+	assert.NoError(t, err)
+
+	createdAt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, []testUser{
+		{
+			ID:             1,
+			Name:           "foo",
+			Nickname:       optional.Some[string]("f"),
+			Age:            optional.Some[int](20),
+			Avatar:         optional.Some[[]byte]([]byte{1}),
+			testTimestamps: testTimestamps{CreatedAt: createdAt},
+		},
+		{
+			ID:             2,
+			Name:           "bar",
+			Nickname:       optional.None[string](),
+			Age:            optional.None[int](),
+			Avatar:         optional.None[[]byte](),
+			testTimestamps: testTimestamps{CreatedAt: createdAt},
+		},
+	}, users)
+}
+
+func TestScanAll_empty(t *testing.T) {
+	db := openTestDB(t)
+
+	rows, err := db.Query("SELECT id, name FROM users WHERE id = 0")
+	assert.NoError(t, err)
+
+	users, err := ScanAll[testUser](rows)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.NotNil(t, users)
+}
+
+func TestScanOne(t *testing.T) {
+	db := openTestDB(t)
+
+	rows, err := db.Query("SELECT name, nickname FROM users WHERE id = 2")
+	assert.NoError(t, err)
+	user, err := ScanOne[testUser](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", user.Name)
+	assert.True(t, user.Nickname.IsNone())
+
+	rows, err = db.Query("SELECT name FROM users WHERE id = 0")
+	assert.NoError(t, err)
+	_, err = ScanOne[testUser](rows)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestScan(t *testing.T) {
+	db := openTestDB(t)
+
+	// the embedded struct via pointer must be exported to be allocated
+	type Timestamps testTimestamps
+	type embeddedPtr struct {
+		*Timestamps
+		ID int64 `db:"id"`
+	}
+
+	rows, err := db.Query("SELECT id, created_at FROM users WHERE id = 1")
+	assert.NoError(t, err)
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	assert.True(t, rows.Next())
+	var v embeddedPtr
+	err = Scan(rows, &v)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v.ID)
+	assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), v.CreatedAt)
+
+	assert.ErrorIs(t, Scan(rows, v), ErrInvalidDestination)
+}
+
+func TestScanAll_shouldRaiseErrorForNullIntoNonOptionField(t *testing.T) {
+	db := openTestDB(t)
+
+	type nonOption struct {
+		Nickname string `db:"nickname"`
+	}
+	rows, err := db.Query("SELECT nickname FROM users WHERE id = 2")
+	assert.NoError(t, err)
+	_, err = ScanAll[nonOption](rows)
+	assert.Error(t, err)
+
+	type nonOptionBytes struct {
+		Avatar []byte `db:"avatar"`
+	}
+	rows, err = db.Query("SELECT avatar FROM users WHERE id = 2")
+	assert.NoError(t, err)
+	_, err = ScanAll[nonOptionBytes](rows)
+	assert.ErrorContains(t, err, `column "avatar" is NULL`)
+
+	rows, err = db.Query("SELECT avatar FROM users WHERE id = 1")
+	assert.NoError(t, err)
+	got, err := ScanAll[nonOptionBytes](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []nonOptionBytes{{Avatar: []byte{1}}}, got)
+
+	type nonOptionAny struct {
+		Age any `db:"age"`
+	}
+	rows, err = db.Query("SELECT age FROM users WHERE id = 2")
+	assert.NoError(t, err)
+	_, err = ScanAll[nonOptionAny](rows)
+	assert.ErrorContains(t, err, `column "age" is NULL`)
+
+	type nullAware struct {
+		Nickname sql.NullString `db:"nickname"`
+		Age      *int           `db:"age"`
+	}
+	rows, err = db.Query("SELECT nickname, age FROM users WHERE id = 2")
+	assert.NoError(t, err)
+	gotNullAware, err := ScanAll[nullAware](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []nullAware{{}}, gotNullAware)
+}
+
+func TestScanAll_shouldRaiseErrorForUnknownColumn(t *testing.T) {
+	db := openTestDB(t)
+
+	type partial struct {
+		ID int64 `db:"id"`
+	}
+	rows, err := db.Query("SELECT id, name FROM users")
+	assert.NoError(t, err)
+	_, err = ScanAll[partial](rows)
+	assert.ErrorContains(t, err, `missing destination field for column "name"`)
+}
+
+func TestScanAll_shouldRaiseErrorForNonStruct(t *testing.T) {
+	db := openTestDB(t)
+
+	rows, err := db.Query("SELECT id FROM users")
+	assert.NoError(t, err)
+	_, err = ScanAll[int64](rows)
+	assert.ErrorIs(t, err, ErrInvalidDestination)
+}
@@ -0,0 +1,157 @@
+package optional
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// templateOption is an interface to deal with Option[T] values without type parameters, because template functions can't be generic.
+type templateOption interface {
+	IsSome() bool
+	unwrapAsAny() any
+}
+
+func (o Option[T]) unwrapAsAny() any {
+	return o.Unwrap()
+}
+
+// TemplateFuncMap returns the function map for text/template and html/template to deal with Option values.
+// The returned map can be passed to both of text/template.Template#Funcs and html/template.Template#Funcs.
+//
+// The map has the following functions:
+//
+//   - isSome OPTION: returns whether the Option has a value or not
+//   - isNone OPTION: returns whether the Option *doesn't* have a value or not
+//   - unwrap OPTION: returns the contained value, or the default value of the type if the Option is None
+//   - unwrapOr FALLBACK OPTION: returns the contained value, or FALLBACK if the Option is None
+//   - default FALLBACK VALUE: similar to unwrapOr, but this also accepts a non-Option VALUE and returns that as it is (FALLBACK is returned only for nil)
+//   - optionalAttr NAME OPTION: returns the html/template-safe attribute `NAME="value"`, or an empty attribute (i.e. the attribute is omitted) if the Option is None.
+//     NAME must be one of `value`, `placeholder`, `title`, `alt`, `name`, `id`, `class`, `aria-*` and `data-*` (except the names that contain `src`, `url` or `uri`),
+//     because the other attributes (e.g. `href`, `srcdoc`, `style` and `on*`) need the contextual escaping by html/template.
+//
+// Note that `{{.Field}}` renders the contained value through Option#String(), and None renders an empty string.
+// The nil value and the nil pointer of Option are dealt with as None.
+func TemplateFuncMap() map[string]any {
+	return map[string]any{
+		"isSome":       templateIsSome,
+		"isNone":       templateIsNone,
+		"unwrap":       templateUnwrap,
+		"unwrapOr":     templateUnwrapOr,
+		"default":      templateDefault,
+		"optionalAttr": templateOptionalAttr,
+	}
+}
+
+// asTemplateOption converts the given template argument into the Option.
+// The second return value reports whether the argument is an Option (or nil) or not.
+func asTemplateOption(v any) (templateOption, bool) {
+	if v == nil {
+		return None[any](), true
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		if _, ok := v.(templateOption); ok {
+			return None[any](), true
+		}
+	}
+	opt, ok := v.(templateOption)
+	return opt, ok
+}
+
+func mustTemplateOption(funcName string, v any) (templateOption, error) {
+	opt, ok := asTemplateOption(v)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected Option value but got %T", funcName, v)
+	}
+	return opt, nil
+}
+
+func templateIsSome(v any) (bool, error) {
+	opt, err := mustTemplateOption("isSome", v)
+	if err != nil {
+		return false, err
+	}
+	return opt.IsSome(), nil
+}
+
+func templateIsNone(v any) (bool, error) {
+	opt, err := mustTemplateOption("isNone", v)
+	if err != nil {
+		return false, err
+	}
+	return !opt.IsSome(), nil
+}
+
+func templateUnwrap(v any) (any, error) {
+	opt, err := mustTemplateOption("unwrap", v)
+	if err != nil {
+		return nil, err
+	}
+	return opt.unwrapAsAny(), nil
+}
+
+func templateUnwrapOr(fallbackValue any, v any) (any, error) {
+	opt, err := mustTemplateOption("unwrapOr", v)
+	if err != nil {
+		return nil, err
+	}
+	if !opt.IsSome() {
+		return fallbackValue, nil
+	}
+	return opt.unwrapAsAny(), nil
+}
+
+func templateDefault(fallbackValue any, v any) any {
+	opt, ok := asTemplateOption(v)
+	if !ok {
+		return v
+	}
+	if !opt.IsSome() {
+		return fallbackValue
+	}
+	return opt.unwrapAsAny()
+}
+
+func templateOptionalAttr(name string, v any) (template.HTMLAttr, error) {
+	if !isSafeHTMLAttrName(name) {
+		return "", fmt.Errorf("optionalAttr: invalid attribute name %q", name)
+	}
+	opt, err := mustTemplateOption("optionalAttr", v)
+	if err != nil {
+		return "", err
+	}
+	if !opt.IsSome() {
+		return "", nil
+	}
+	return template.HTMLAttr(name + `="` + template.HTMLEscapeString(fmt.Sprint(opt.unwrapAsAny())) + `"`), nil
+}
+
+// safeHTMLAttrNames is a set of the attribute names whose value is plain text for html/template.
+var safeHTMLAttrNames = map[string]struct{}{
+	"alt": {}, "class": {}, "id": {}, "name": {}, "placeholder": {}, "title": {}, "value": {},
+}
+
+// isSafeHTMLAttrName returns whether the attribute can be emitted with the HTML-escaped value as the trusted HTMLAttr.
+// This accepts only the allowlisted attributes, `aria-*` and `data-*`; the `data-*` attributes that html/template deals with as URL (i.e. the name contains `src`, `url` or `uri`) are refused.
+func isSafeHTMLAttrName(name string) bool {
+	for _, c := range name {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-':
+		default:
+			return false
+		}
+	}
+
+	lowerName := strings.ToLower(name)
+	if _, ok := safeHTMLAttrNames[lowerName]; ok {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(lowerName, "aria-"); ok {
+		return len(suffix) > 0
+	}
+	if suffix, ok := strings.CutPrefix(lowerName, "data-"); ok {
+		return len(suffix) > 0 && !strings.Contains(suffix, "src") && !strings.Contains(suffix, "url") && !strings.Contains(suffix, "uri")
+	}
+	return false
+}
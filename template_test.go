@@ -0,0 +1,123 @@
+package optional
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type templateTestStruct struct {
+	Name     Option[string]
+	Nickname Option[string]
+	Age      *Option[int]
+	Plain    string
+}
+
+func executeTextTemplate(t *testing.T, tmpl string, data any) (string, error) {
+	t.Helper()
+	parsed, err := texttemplate.New("").Funcs(TemplateFuncMap()).Parse(tmpl)
+	assert.NoError(t, err)
+	var buf strings.Builder
+	err = parsed.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func executeHTMLTemplate(t *testing.T, tmpl string, data any) (string, error) {
+	t.Helper()
+	parsed, err := htmltemplate.New("").Funcs(TemplateFuncMap()).Parse(tmpl)
+	assert.NoError(t, err)
+	var buf strings.Builder
+	err = parsed.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestTemplateFuncMap_TextTemplate(t *testing.T) {
+	data := templateTestStruct{
+		Name:     Some[string]("moznion"),
+		Nickname: None[string](),
+		Plain:    "plain",
+	}
+
+	got, err := executeTextTemplate(t, `{{isSome .Name}} {{isNone .Name}} {{isSome .Nickname}} {{isNone .Nickname}} {{isNone .Age}}`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "true false false true true", got)
+
+	got, err = executeTextTemplate(t, `{{unwrap .Name}}|{{unwrap .Nickname}}|{{.Nickname | unwrapOr "n/a"}}|{{.Name | unwrapOr "n/a"}}`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "moznion||n/a|moznion", got)
+
+	got, err = executeTextTemplate(t, `{{.Name | default "-"}}|{{.Nickname | default "-"}}|{{.Plain | default "-"}}|{{.Age | default "-"}}`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "moznion|-|plain|-", got)
+
+	age := Some[int](42)
+	data.Age = &age
+	got, err = executeTextTemplate(t, `{{if isSome .Age}}{{unwrap .Age}}{{end}}`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", got)
+}
+
+func TestTemplateFuncMap_shouldRaiseErrorForNonOptionValue(t *testing.T) {
+	for _, tmpl := range []string{`{{isSome .Plain}}`, `{{isNone .Plain}}`, `{{unwrap .Plain}}`, `{{unwrapOr "x" .Plain}}`} {
+		_, err := executeTextTemplate(t, tmpl, templateTestStruct{Plain: "plain"})
+		assert.Error(t, err, tmpl)
+	}
+}
+
+func TestTemplateFuncMap_HTMLTemplate(t *testing.T) {
+	data := templateTestStruct{
+		Name:     Some[string](`"><script>alert(1)</script>`),
+		Nickname: None[string](),
+	}
+
+	got, err := executeHTMLTemplate(t, `<input {{optionalAttr "value" .Name}}><input {{optionalAttr "placeholder" .Nickname}}>`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, `<input value="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"><input >`, got)
+
+	got, err = executeHTMLTemplate(t, `<p>{{unwrap .Name}}</p>`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, `<p>&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;</p>`, got)
+}
+
+func TestTemplate_fieldShouldRenderContainedValue(t *testing.T) {
+	data := templateTestStruct{
+		Name:     Some[string]("moznion"),
+		Nickname: None[string](),
+	}
+
+	got, err := executeTextTemplate(t, `{{.Name}}|{{.Nickname}}`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "moznion|", got)
+
+	got, err = executeHTMLTemplate(t, `<p>{{.Name}}</p><p>{{.Nickname}}</p>`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>moznion</p><p></p>", got)
+
+	data.Name = Some[string](`"><script>alert(1)</script>`)
+	got, err = executeHTMLTemplate(t, `<p>{{.Name}}</p><input value="{{.Name}}">`, data)
+	assert.NoError(t, err)
+	assert.Equal(t, `<p>&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;</p><input value="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">`, got)
+}
+
+func TestTemplateFuncMap_optionalAttrShouldRefuseUnsafeAttributeName(t *testing.T) {
+	data := templateTestStruct{Name: Some[string]("foo")}
+	for _, name := range []string{
+		"onclick", "OnLoad", "href", "SRC", "style", `x"y`, "xlink:href", "",
+		"srcdoc", "content", "http-equiv", "lowsrc", "data-src", "data-Image-URL", "data-uri", "data-", "aria-", "formaction",
+	} {
+		_, err := executeHTMLTemplate(t, `<a {{optionalAttr "`+strings.ReplaceAll(name, `"`, `\"`)+`" .Name}}>`, data)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTemplateFuncMap_optionalAttrShouldAcceptAllowlistedAttributeName(t *testing.T) {
+	data := templateTestStruct{Name: Some[string]("foo")}
+	for _, name := range []string{"value", "placeholder", "title", "alt", "name", "id", "Class", "aria-label", "data-user-name"} {
+		got, err := executeHTMLTemplate(t, `<input {{optionalAttr "`+name+`" .Name}}>`, data)
+		assert.NoError(t, err, name)
+		assert.Equal(t, `<input `+name+`="foo">`, got)
+	}
+}
@@ -0,0 +1,256 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Triple is a data type that represents a tuple that has three elements.
+// This is encoded into a JSON array (e.g. `[1,"foo",true]`), and decoded from a JSON array that has exactly three elements.
+// Note that Pair keeps the JSON object representation (i.e. `{"Value1":...,"Value2":...}`) for backward compatibility.
+type Triple[T1, T2, T3 any] struct {
+	Value1 T1
+	Value2 T2
+	Value3 T3
+}
+
+// MarshalJSON encodes the Triple into a JSON array.
+func (t Triple[T1, T2, T3]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.Value1, t.Value2, t.Value3})
+}
+
+// UnmarshalJSON decodes the JSON array into the Triple. The JSON array must have exactly three elements.
+// JSON null leaves the Triple as it is, in the same manner as encoding/json does for the other types.
+func (t *Triple[T1, T2, T3]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONTuple(data, &t.Value1, &t.Value2, &t.Value3)
+}
+
+// Zip3 zips three Options into a Triple that has each Option's value.
+// If any one of the Options is None, this also returns None.
+func Zip3[T1, T2, T3 any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3]) Option[Triple[T1, T2, T3]] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() {
+		return Some(Triple[T1, T2, T3]{
+			Value1: opt1[value],
+			Value2: opt2[value],
+			Value3: opt3[value],
+		})
+	}
+
+	return None[Triple[T1, T2, T3]]()
+}
+
+// Zip3With zips three Options into a typed value according to the zipper function.
+// If any one of the Options is None, this also returns None.
+func Zip3With[T1, T2, T3, U any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], zipper func(v1 T1, v2 T2, v3 T3) U) Option[U] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() {
+		return Some(zipper(opt1[value], opt2[value], opt3[value]))
+	}
+	return None[U]()
+}
+
+// Unzip3 extracts the values from a Triple and pack them into each Option value.
+// If the given zipped value is None, this returns None for all return values.
+func Unzip3[T1, T2, T3 any](zipped Option[Triple[T1, T2, T3]]) (Option[T1], Option[T2], Option[T3]) {
+	if zipped.IsNone() {
+		return None[T1](), None[T2](), None[T3]()
+	}
+
+	tuple := zipped[value]
+	return Some(tuple.Value1), Some(tuple.Value2), Some(tuple.Value3)
+}
+
+// Quadruple is a data type that represents a tuple that has four elements.
+// This is encoded into a JSON array (e.g. `[1,"foo",true,1.5]`), and decoded from a JSON array that has exactly four elements.
+type Quadruple[T1, T2, T3, T4 any] struct {
+	Value1 T1
+	Value2 T2
+	Value3 T3
+	Value4 T4
+}
+
+// MarshalJSON encodes the Quadruple into a JSON array.
+func (t Quadruple[T1, T2, T3, T4]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.Value1, t.Value2, t.Value3, t.Value4})
+}
+
+// UnmarshalJSON decodes the JSON array into the Quadruple. The JSON array must have exactly four elements.
+// JSON null leaves the Quadruple as it is, in the same manner as encoding/json does for the other types.
+func (t *Quadruple[T1, T2, T3, T4]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONTuple(data, &t.Value1, &t.Value2, &t.Value3, &t.Value4)
+}
+
+// Zip4 zips four Options into a Quadruple that has each Option's value.
+// If any one of the Options is None, this also returns None.
+func Zip4[T1, T2, T3, T4 any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4]) Option[Quadruple[T1, T2, T3, T4]] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() {
+		return Some(Quadruple[T1, T2, T3, T4]{
+			Value1: opt1[value],
+			Value2: opt2[value],
+			Value3: opt3[value],
+			Value4: opt4[value],
+		})
+	}
+
+	return None[Quadruple[T1, T2, T3, T4]]()
+}
+
+// Zip4With zips four Options into a typed value according to the zipper function.
+// If any one of the Options is None, this also returns None.
+func Zip4With[T1, T2, T3, T4, U any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4], zipper func(v1 T1, v2 T2, v3 T3, v4 T4) U) Option[U] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() {
+		return Some(zipper(opt1[value], opt2[value], opt3[value], opt4[value]))
+	}
+	return None[U]()
+}
+
+// Unzip4 extracts the values from a Quadruple and pack them into each Option value.
+// If the given zipped value is None, this returns None for all return values.
+func Unzip4[T1, T2, T3, T4 any](zipped Option[Quadruple[T1, T2, T3, T4]]) (Option[T1], Option[T2], Option[T3], Option[T4]) {
+	if zipped.IsNone() {
+		return None[T1](), None[T2](), None[T3](), None[T4]()
+	}
+
+	tuple := zipped[value]
+	return Some(tuple.Value1), Some(tuple.Value2), Some(tuple.Value3), Some(tuple.Value4)
+}
+
+// Quintuple is a data type that represents a tuple that has five elements.
+// This is encoded into a JSON array (e.g. `[1,"foo",true,1.5,null]`), and decoded from a JSON array that has exactly five elements.
+type Quintuple[T1, T2, T3, T4, T5 any] struct {
+	Value1 T1
+	Value2 T2
+	Value3 T3
+	Value4 T4
+	Value5 T5
+}
+
+// MarshalJSON encodes the Quintuple into a JSON array.
+func (t Quintuple[T1, T2, T3, T4, T5]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.Value1, t.Value2, t.Value3, t.Value4, t.Value5})
+}
+
+// UnmarshalJSON decodes the JSON array into the Quintuple. The JSON array must have exactly five elements.
+// JSON null leaves the Quintuple as it is, in the same manner as encoding/json does for the other types.
+func (t *Quintuple[T1, T2, T3, T4, T5]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONTuple(data, &t.Value1, &t.Value2, &t.Value3, &t.Value4, &t.Value5)
+}
+
+// Zip5 zips five Options into a Quintuple that has each Option's value.
+// If any one of the Options is None, this also returns None.
+func Zip5[T1, T2, T3, T4, T5 any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4], opt5 Option[T5]) Option[Quintuple[T1, T2, T3, T4, T5]] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() && opt5.IsSome() {
+		return Some(Quintuple[T1, T2, T3, T4, T5]{
+			Value1: opt1[value],
+			Value2: opt2[value],
+			Value3: opt3[value],
+			Value4: opt4[value],
+			Value5: opt5[value],
+		})
+	}
+
+	return None[Quintuple[T1, T2, T3, T4, T5]]()
+}
+
+// Zip5With zips five Options into a typed value according to the zipper function.
+// If any one of the Options is None, this also returns None.
+func Zip5With[T1, T2, T3, T4, T5, U any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4], opt5 Option[T5], zipper func(v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) U) Option[U] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() && opt5.IsSome() {
+		return Some(zipper(opt1[value], opt2[value], opt3[value], opt4[value], opt5[value]))
+	}
+	return None[U]()
+}
+
+// Unzip5 extracts the values from a Quintuple and pack them into each Option value.
+// If the given zipped value is None, this returns None for all return values.
+func Unzip5[T1, T2, T3, T4, T5 any](zipped Option[Quintuple[T1, T2, T3, T4, T5]]) (Option[T1], Option[T2], Option[T3], Option[T4], Option[T5]) {
+	if zipped.IsNone() {
+		return None[T1](), None[T2](), None[T3](), None[T4](), None[T5]()
+	}
+
+	tuple := zipped[value]
+	return Some(tuple.Value1), Some(tuple.Value2), Some(tuple.Value3), Some(tuple.Value4), Some(tuple.Value5)
+}
+
+// Sextuple is a data type that represents a tuple that has six elements.
+// This is encoded into a JSON array (e.g. `[1,"foo",true,1.5,null,"bar"]`), and decoded from a JSON array that has exactly six elements.
+type Sextuple[T1, T2, T3, T4, T5, T6 any] struct {
+	Value1 T1
+	Value2 T2
+	Value3 T3
+	Value4 T4
+	Value5 T5
+	Value6 T6
+}
+
+// MarshalJSON encodes the Sextuple into a JSON array.
+func (t Sextuple[T1, T2, T3, T4, T5, T6]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.Value1, t.Value2, t.Value3, t.Value4, t.Value5, t.Value6})
+}
+
+// UnmarshalJSON decodes the JSON array into the Sextuple. The JSON array must have exactly six elements.
+// JSON null leaves the Sextuple as it is, in the same manner as encoding/json does for the other types.
+func (t *Sextuple[T1, T2, T3, T4, T5, T6]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONTuple(data, &t.Value1, &t.Value2, &t.Value3, &t.Value4, &t.Value5, &t.Value6)
+}
+
+// Zip6 zips six Options into a Sextuple that has each Option's value.
+// If any one of the Options is None, this also returns None.
+func Zip6[T1, T2, T3, T4, T5, T6 any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4], opt5 Option[T5], opt6 Option[T6]) Option[Sextuple[T1, T2, T3, T4, T5, T6]] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() && opt5.IsSome() && opt6.IsSome() {
+		return Some(Sextuple[T1, T2, T3, T4, T5, T6]{
+			Value1: opt1[value],
+			Value2: opt2[value],
+			Value3: opt3[value],
+			Value4: opt4[value],
+			Value5: opt5[value],
+			Value6: opt6[value],
+		})
+	}
+
+	return None[Sextuple[T1, T2, T3, T4, T5, T6]]()
+}
+
+// Zip6With zips six Options into a typed value according to the zipper function.
+// If any one of the Options is None, this also returns None.
+func Zip6With[T1, T2, T3, T4, T5, T6, U any](opt1 Option[T1], opt2 Option[T2], opt3 Option[T3], opt4 Option[T4], opt5 Option[T5], opt6 Option[T6], zipper func(v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6) U) Option[U] {
+	if opt1.IsSome() && opt2.IsSome() && opt3.IsSome() && opt4.IsSome() && opt5.IsSome() && opt6.IsSome() {
+		return Some(zipper(opt1[value], opt2[value], opt3[value], opt4[value], opt5[value], opt6[value]))
+	}
+	return None[U]()
+}
+
+// Unzip6 extracts the values from a Sextuple and pack them into each Option value.
+// If the given zipped value is None, this returns None for all return values.
+func Unzip6[T1, T2, T3, T4, T5, T6 any](zipped Option[Sextuple[T1, T2, T3, T4, T5, T6]]) (Option[T1], Option[T2], Option[T3], Option[T4], Option[T5], Option[T6]) {
+	if zipped.IsNone() {
+		return None[T1](), None[T2](), None[T3](), None[T4](), None[T5](), None[T6]()
+	}
+
+	tuple := zipped[value]
+	return Some(tuple.Value1), Some(tuple.Value2), Some(tuple.Value3), Some(tuple.Value4), Some(tuple.Value5), Some(tuple.Value6)
+}
+
+// unmarshalJSONTuple decodes the JSON array into the elements of the tuple. The number of the array elements must be the same as the number of the tuple elements.
+func unmarshalJSONTuple(data []byte, elements ...any) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		return nil
+	}
+
+	var raws []json.RawMessage
+	err := json.Unmarshal(data, &raws)
+	if err != nil {
+		return err
+	}
+	if len(raws) != len(elements) {
+		return fmt.Errorf("JSON array for the tuple must have %d elements, but got %d elements", len(elements), len(raws))
+	}
+
+	for i, raw := range raws {
+		err := json.Unmarshal(raw, elements[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
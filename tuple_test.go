@@ -0,0 +1,208 @@
+package optional
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip3(t *testing.T) {
+	zipped := Zip3(Some[int](123), Some[string]("foo"), Some[bool](true))
+	assert.True(t, zipped.IsSome())
+	assert.Equal(t, Triple[int, string, bool]{
+		Value1: 123,
+		Value2: "foo",
+		Value3: true,
+	}, zipped[value])
+
+	assert.True(t, Zip3(None[int](), Some[string]("foo"), Some[bool](true)).IsNone())
+	assert.True(t, Zip3(Some[int](123), None[string](), Some[bool](true)).IsNone())
+	assert.True(t, Zip3(Some[int](123), Some[string]("foo"), None[bool]()).IsNone())
+}
+
+func TestZip4(t *testing.T) {
+	zipped := Zip4(Some[int](1), Some[string]("2"), Some[bool](true), Some[float64](4.5))
+	assert.Equal(t, Some(Quadruple[int, string, bool, float64]{
+		Value1: 1,
+		Value2: "2",
+		Value3: true,
+		Value4: 4.5,
+	}), zipped)
+
+	assert.True(t, Zip4(Some[int](1), Some[string]("2"), Some[bool](true), None[float64]()).IsNone())
+}
+
+func TestZip5(t *testing.T) {
+	zipped := Zip5(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[string]("5"))
+	assert.Equal(t, Some(Quintuple[int, int, int, int, string]{
+		Value1: 1,
+		Value2: 2,
+		Value3: 3,
+		Value4: 4,
+		Value5: "5",
+	}), zipped)
+
+	assert.True(t, Zip5(Some[int](1), None[int](), Some[int](3), Some[int](4), Some[string]("5")).IsNone())
+}
+
+func TestZip6(t *testing.T) {
+	zipped := Zip6(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), Some[string]("6"))
+	assert.Equal(t, Some(Sextuple[int, int, int, int, int, string]{
+		Value1: 1,
+		Value2: 2,
+		Value3: 3,
+		Value4: 4,
+		Value5: 5,
+		Value6: "6",
+	}), zipped)
+
+	assert.True(t, Zip6(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), None[string]()).IsNone())
+}
+
+func TestZipNWith(t *testing.T) {
+	type User struct {
+		ID        int64
+		Name      string
+		Email     string
+		CreatedAt time.Time
+	}
+
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	newUser := func(id int64, name string, email string, createdAt time.Time) User {
+		return User{
+			ID:        id,
+			Name:      name,
+			Email:     email,
+			CreatedAt: createdAt,
+		}
+	}
+
+	user := Zip4With(Some[int64](1), Some[string]("foo"), Some[string]("foo@example.com"), Some[time.Time](createdAt), newUser)
+	assert.Equal(t, Some(User{
+		ID:        1,
+		Name:      "foo",
+		Email:     "foo@example.com",
+		CreatedAt: createdAt,
+	}), user)
+	assert.True(t, Zip4With(Some[int64](1), Some[string]("foo"), None[string](), Some[time.Time](createdAt), newUser).IsNone())
+
+	sum3 := func(v1, v2, v3 int) string {
+		return strconv.Itoa(v1 + v2 + v3)
+	}
+	assert.Equal(t, Some[string]("6"), Zip3With(Some[int](1), Some[int](2), Some[int](3), sum3))
+	assert.True(t, Zip3With(None[int](), Some[int](2), Some[int](3), sum3).IsNone())
+
+	sum5 := func(v1, v2, v3, v4, v5 int) int {
+		return v1 + v2 + v3 + v4 + v5
+	}
+	assert.Equal(t, Some[int](15), Zip5With(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), sum5))
+	assert.True(t, Zip5With(Some[int](1), Some[int](2), Some[int](3), Some[int](4), None[int](), sum5).IsNone())
+
+	sum6 := func(v1, v2, v3, v4, v5, v6 int) int {
+		return v1 + v2 + v3 + v4 + v5 + v6
+	}
+	assert.Equal(t, Some[int](21), Zip6With(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), Some[int](6), sum6))
+	assert.True(t, Zip6With(Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), None[int](), sum6).IsNone())
+}
+
+func TestUnzipN(t *testing.T) {
+	o1, o2, o3 := Unzip3(Zip3(Some[int](1), Some[string]("2"), Some[bool](true)))
+	assert.Equal(t, Some[int](1), o1)
+	assert.Equal(t, Some[string]("2"), o2)
+	assert.Equal(t, Some[bool](true), o3)
+
+	o1, o2, o3 = Unzip3(None[Triple[int, string, bool]]())
+	assert.True(t, o1.IsNone())
+	assert.True(t, o2.IsNone())
+	assert.True(t, o3.IsNone())
+
+	q1, q2, q3, q4 := Unzip4(Some(Quadruple[int, int, int, int]{1, 2, 3, 4}))
+	assert.Equal(t, []Option[int]{Some[int](1), Some[int](2), Some[int](3), Some[int](4)}, []Option[int]{q1, q2, q3, q4})
+	q1, q2, q3, q4 = Unzip4(None[Quadruple[int, int, int, int]]())
+	assert.Equal(t, []Option[int]{None[int](), None[int](), None[int](), None[int]()}, []Option[int]{q1, q2, q3, q4})
+
+	r1, r2, r3, r4, r5 := Unzip5(Some(Quintuple[int, int, int, int, int]{1, 2, 3, 4, 5}))
+	assert.Equal(t, []Option[int]{Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5)}, []Option[int]{r1, r2, r3, r4, r5})
+	r1, r2, r3, r4, r5 = Unzip5(None[Quintuple[int, int, int, int, int]]())
+	assert.Equal(t, []Option[int]{None[int](), None[int](), None[int](), None[int](), None[int]()}, []Option[int]{r1, r2, r3, r4, r5})
+
+	s1, s2, s3, s4, s5, s6 := Unzip6(Some(Sextuple[int, int, int, int, int, int]{1, 2, 3, 4, 5, 6}))
+	assert.Equal(t, []Option[int]{Some[int](1), Some[int](2), Some[int](3), Some[int](4), Some[int](5), Some[int](6)}, []Option[int]{s1, s2, s3, s4, s5, s6})
+	s1, s2, s3, s4, s5, s6 = Unzip6(None[Sextuple[int, int, int, int, int, int]]())
+	assert.Equal(t, []Option[int]{None[int](), None[int](), None[int](), None[int](), None[int](), None[int]()}, []Option[int]{s1, s2, s3, s4, s5, s6})
+}
+
+func TestTuple_MarshalJSON(t *testing.T) {
+	marshaled, err := json.Marshal(Triple[int, string, Option[bool]]{Value1: 1, Value2: "foo", Value3: None[bool]()})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,"foo",null]`, string(marshaled))
+
+	marshaled, err = json.Marshal(Quadruple[int, int, int, string]{1, 2, 3, "4"})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3,"4"]`, string(marshaled))
+
+	marshaled, err = json.Marshal(Quintuple[int, int, int, int, string]{1, 2, 3, 4, "5"})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3,4,"5"]`, string(marshaled))
+
+	marshaled, err = json.Marshal(Some(Sextuple[int, int, int, int, int, string]{1, 2, 3, 4, 5, "6"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3,4,5,"6"]`, string(marshaled))
+
+	// Pair keeps the JSON object representation
+	marshaled, err = json.Marshal(Pair[int, string]{Value1: 1, Value2: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Value1":1,"Value2":"foo"}`, string(marshaled))
+}
+
+func TestTuple_UnmarshalJSON(t *testing.T) {
+	var triple Triple[int, string, Option[bool]]
+	err := json.Unmarshal([]byte(`[1, "foo", null]`), &triple)
+	assert.NoError(t, err)
+	assert.Equal(t, Triple[int, string, Option[bool]]{Value1: 1, Value2: "foo", Value3: None[bool]()}, triple)
+
+	var quadruple Quadruple[int, int, int, string]
+	err = json.Unmarshal([]byte(`[1,2,3,"4"]`), &quadruple)
+	assert.NoError(t, err)
+	assert.Equal(t, Quadruple[int, int, int, string]{1, 2, 3, "4"}, quadruple)
+
+	var quintuple Quintuple[int, int, int, int, string]
+	err = json.Unmarshal([]byte(`[1,2,3,4,"5"]`), &quintuple)
+	assert.NoError(t, err)
+	assert.Equal(t, Quintuple[int, int, int, int, string]{1, 2, 3, 4, "5"}, quintuple)
+
+	var sextuple Option[Sextuple[int, int, int, int, int, string]]
+	err = json.Unmarshal([]byte(`[1,2,3,4,5,"6"]`), &sextuple)
+	assert.NoError(t, err)
+	assert.Equal(t, Some(Sextuple[int, int, int, int, int, string]{1, 2, 3, 4, 5, "6"}), sextuple)
+
+	err = json.Unmarshal([]byte(`null`), &sextuple)
+	assert.NoError(t, err)
+	assert.True(t, sextuple.IsNone())
+
+	var s struct {
+		Triple Triple[int, int, int] `json:"triple"`
+	}
+	err = json.Unmarshal([]byte(`{"triple":null}`), &s)
+	assert.NoError(t, err)
+	assert.Equal(t, Triple[int, int, int]{}, s.Triple)
+}
+
+func TestTuple_UnmarshalJSON_shouldRaiseError(t *testing.T) {
+	var triple Triple[int, string, bool]
+
+	err := json.Unmarshal([]byte(`[1,"foo"]`), &triple)
+	assert.ErrorContains(t, err, "must have 3 elements, but got 2 elements")
+
+	err = json.Unmarshal([]byte(`[1,"foo",true,false]`), &triple)
+	assert.ErrorContains(t, err, "must have 3 elements, but got 4 elements")
+
+	err = json.Unmarshal([]byte(`{"Value1":1,"Value2":"foo","Value3":true}`), &triple)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`[1,2,true]`), &triple)
+	assert.Error(t, err)
+}